@@ -0,0 +1,117 @@
+package env
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	chatDeleteGracePeriodEnvName = "CHAT_DELETE_GRACE_PERIOD"
+	chatPurgeIntervalEnvName     = "CHAT_PURGE_INTERVAL"
+
+	chatPresenceHeartbeatIntervalEnvName = "CHAT_PRESENCE_HEARTBEAT_INTERVAL"
+	chatPresenceGracePeriodEnvName       = "CHAT_PRESENCE_GRACE_PERIOD"
+)
+
+// ChatConfig - интерфейс конфига с настройками жизненного цикла чатов.
+//
+// Методы:
+//   - DeleteGracePeriod() time.Duration: время, в течение которого удаленный чат можно восстановить.
+//   - PurgeInterval() time.Duration: периодичность запуска окончательного удаления чатов с истекшим grace-периодом.
+//   - PresenceHeartbeatInterval() time.Duration: периодичность подтверждения активных подключений пользователей.
+//   - PresenceGracePeriod() time.Duration: время, в течение которого пользователь считается онлайн после
+//     последнего подтверждения подключения.
+type ChatConfig interface {
+	DeleteGracePeriod() time.Duration
+	PurgeInterval() time.Duration
+	PresenceHeartbeatInterval() time.Duration
+	PresenceGracePeriod() time.Duration
+}
+
+// chatConfig - структура конфига чатов, реализующая интерфейс ChatConfig.
+type chatConfig struct {
+	deleteGracePeriod         time.Duration
+	purgeInterval             time.Duration
+	presenceHeartbeatInterval time.Duration
+	presenceGracePeriod       time.Duration
+}
+
+// NewChatConfig - метод создания конфига чатов, реализующего интерфейс ChatConfig.
+// Параметры конфига берутся из переменных окружения программы в формате time.ParseDuration (например, "72h").
+//
+// Возвращает:
+//   - ChatConfig: созданный объект конфига чатов.
+//   - error: ошибка, если что-то пошло не так.
+func NewChatConfig() (ChatConfig, error) {
+	deleteGracePeriod, err := durationFromEnv(chatDeleteGracePeriodEnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	purgeInterval, err := durationFromEnv(chatPurgeIntervalEnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	presenceHeartbeatInterval, err := durationFromEnv(chatPresenceHeartbeatIntervalEnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	presenceGracePeriod, err := durationFromEnv(chatPresenceGracePeriodEnvName)
+	if err != nil {
+		return nil, err
+	}
+	// Активное подключение должно подтверждаться чаще, чем истекает grace-период
+	if presenceHeartbeatInterval >= presenceGracePeriod {
+		return nil, errors.Errorf("%s must be less than %s", chatPresenceHeartbeatIntervalEnvName, chatPresenceGracePeriodEnvName)
+	}
+
+	return &chatConfig{
+		deleteGracePeriod:         deleteGracePeriod,
+		purgeInterval:             purgeInterval,
+		presenceHeartbeatInterval: presenceHeartbeatInterval,
+		presenceGracePeriod:       presenceGracePeriod,
+	}, nil
+}
+
+// DeleteGracePeriod - возвращает время, в течение которого удаленный чат можно восстановить.
+func (cfg *chatConfig) DeleteGracePeriod() time.Duration {
+	return cfg.deleteGracePeriod
+}
+
+// PurgeInterval - возвращает периодичность окончательного удаления чатов.
+func (cfg *chatConfig) PurgeInterval() time.Duration {
+	return cfg.purgeInterval
+}
+
+// PresenceHeartbeatInterval - возвращает периодичность подтверждения активных подключений пользователей.
+func (cfg *chatConfig) PresenceHeartbeatInterval() time.Duration {
+	return cfg.presenceHeartbeatInterval
+}
+
+// PresenceGracePeriod - возвращает время, в течение которого пользователь считается онлайн
+// после последнего подтверждения подключения.
+func (cfg *chatConfig) PresenceGracePeriod() time.Duration {
+	return cfg.presenceGracePeriod
+}
+
+// durationFromEnv читает положительную длительность из переменной окружения name.
+func durationFromEnv(name string) (time.Duration, error) {
+	value := os.Getenv(name)
+	if len(value) == 0 {
+		return 0, errors.Errorf("%s not found", name)
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s", name)
+	}
+	if duration <= 0 {
+		return 0, errors.Errorf("%s must be positive", name)
+	}
+
+	return duration, nil
+}
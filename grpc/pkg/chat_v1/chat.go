@@ -2,6 +2,8 @@ package chat_v1
 
 import (
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -13,12 +15,58 @@ var (
 	_ pkg.Validator = (*CreateChatRequest)(nil)
 	_ pkg.Validator = (*DeleteChatRequest)(nil)
 	_ pkg.Validator = (*SendMessageRequest)(nil)
+	_ pkg.Validator = (*GetChatRequest)(nil)
+	_ pkg.Validator = (*ListMessagesRequest)(nil)
+	_ pkg.Validator = (*ConnectChatRequest)(nil)
+	_ pkg.Validator = (*RestoreChatRequest)(nil)
+	_ pkg.Validator = (*AddChatMembersRequest)(nil)
+	_ pkg.Validator = (*RemoveChatMembersRequest)(nil)
+	_ pkg.Validator = (*UpdateChatRequest)(nil)
+	_ pkg.Validator = (*ListChatsRequest)(nil)
+	_ pkg.Validator = (*EditMessageRequest)(nil)
+	_ pkg.Validator = (*DeleteMessageRequest)(nil)
+	_ pkg.Validator = (*SetChatMemberRoleRequest)(nil)
+	_ pkg.Validator = (*ListThreadRequest)(nil)
+	_ pkg.Validator = (*AddReactionRequest)(nil)
+	_ pkg.Validator = (*RemoveReactionRequest)(nil)
+	_ pkg.Validator = (*MarkReadRequest)(nil)
+	_ pkg.Validator = (*TypingRequest)(nil)
+	_ pkg.Validator = (*GetPresenceRequest)(nil)
+	_ pkg.Validator = (*GetOrCreateDirectChatRequest)(nil)
+	_ pkg.Validator = (*SearchMessagesRequest)(nil)
+)
+
+// Поля чата, которые можно обновить через UpdateChat.
+const (
+	UpdateChatFieldName        = "chat_name"
+	UpdateChatFieldDescription = "chat_description"
+)
+
+const (
+	// MaxListMessagesLimit - максимальное количество сообщений, возвращаемых за один запрос ListMessages.
+	MaxListMessagesLimit = 100
+	// MaxListChatsLimit - максимальное количество чатов, возвращаемых за один запрос ListChats.
+	MaxListChatsLimit = 100
+	// MaxListThreadLimit - максимальное количество ответов, возвращаемых за один запрос ListThread.
+	MaxListThreadLimit = 100
+	// MaxReactionEmojiLength - максимальная длина реакции в байтах.
+	MaxReactionEmojiLength = 32
+	// MaxGetPresenceUsers - максимальное количество пользователей в одном запросе GetPresence.
+	MaxGetPresenceUsers = 100
+	// MaxIdempotencyKeyLength - максимальная длина ключа идемпотентности в байтах.
+	MaxIdempotencyKeyLength = 128
+	// MaxSearchMessagesLimit - максимальное количество результатов, возвращаемых за один запрос SearchMessages.
+	MaxSearchMessagesLimit = 50
+	// MaxSearchQueryLength - максимальная длина поискового запроса в байтах.
+	MaxSearchQueryLength = 256
 )
 
 // Validate
 //
 // Возвращает:
 //   - error, если User_IDs пустой.
+//   - error, если Owner_ID отрицательный.
+//   - error, если IdempotencyKey длиннее MaxIdempotencyKeyLength байт.
 //   - error, если ChatName пустой либо состоит только из пробелов.
 //   - nil в остальных случаях.
 func (req *CreateChatRequest) Validate() error {
@@ -28,8 +76,27 @@ func (req *CreateChatRequest) Validate() error {
 		return err
 	}
 
+	// Owner_ID может быть не указан (0), но не может быть отрицательным
+	if req.Owner_ID < 0 {
+		err := status.Error(codes.InvalidArgument, "Owner ID must not be negative")
+		return err
+	}
+
+	if err := validateIdempotencyKey(req.IdempotencyKey); err != nil {
+		return err
+	}
+
+	return validateChatName(req.ChatName)
+}
+
+// validateChatName проверяет название чата.
+//
+// Возвращает:
+//   - error, если название пустое либо состоит только из пробелов.
+//   - nil в остальных случаях.
+func validateChatName(chatName string) error {
 	// ChatName должен содеражть хотя бы 1 символ (не считая пробелов)
-	trimmedChatName := strings.TrimSpace(req.ChatName)
+	trimmedChatName := strings.TrimSpace(chatName)
 	if len(trimmedChatName) == 0 {
 		err := status.Error(codes.InvalidArgument, "Chat name must not be empty")
 		return err
@@ -42,6 +109,7 @@ func (req *CreateChatRequest) Validate() error {
 //
 // Возвращает:
 //   - error, если ID чата не указан.
+//   - error, если ID пользователя не указан.
 //   - nil в остальных случаях.
 func (req *DeleteChatRequest) Validate() error {
 	// В запросе должен содержаться ID чата
@@ -50,17 +118,80 @@ func (req *DeleteChatRequest) Validate() error {
 		return err
 	}
 
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
 	return nil
 }
 
 // Validate
 //
 // Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если ID отправителя не указан.
+//   - error, если ReplyToMessage_ID отрицательный.
+//   - error, если Timestamp указан, но некорректен.
+//   - error, если IdempotencyKey длиннее MaxIdempotencyKeyLength байт.
 //   - error, если Text пустой или состоит только из пробелов.
 //   - nil в остальных случаях.
 func (req *SendMessageRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.Chat_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID отправителя
+	if req.User_IDFrom == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// ReplyToMessage_ID может быть не указан (0), но не может быть отрицательным
+	if req.ReplyToMessage_ID < 0 {
+		err := status.Error(codes.InvalidArgument, "Reply to message ID must not be negative")
+		return err
+	}
+
+	// Timestamp может быть не указан, но если указан, должен быть корректным
+	if req.Timestamp != nil && req.Timestamp.CheckValid() != nil {
+		err := status.Error(codes.InvalidArgument, "Invalid timestamp")
+		return err
+	}
+
+	if err := validateIdempotencyKey(req.IdempotencyKey); err != nil {
+		return err
+	}
+
+	return validateMessageText(req.Text)
+}
+
+// validateIdempotencyKey проверяет ключ идемпотентности. Пустой ключ означает, что ключ не указан.
+//
+// Возвращает:
+//   - error, если ключ длиннее MaxIdempotencyKeyLength байт.
+//   - nil в остальных случаях.
+func validateIdempotencyKey(key string) error {
+	// Ключ идемпотентности не должен быть длиннее MaxIdempotencyKeyLength байт
+	if len(key) > MaxIdempotencyKeyLength {
+		err := status.Errorf(codes.InvalidArgument, "Idempotency key must be at most %d bytes long", MaxIdempotencyKeyLength)
+		return err
+	}
+
+	return nil
+}
+
+// validateMessageText проверяет текст сообщения.
+//
+// Возвращает:
+//   - error, если текст пустой или состоит только из пробелов.
+//   - nil в остальных случаях.
+func validateMessageText(text string) error {
 	// MessageText должен содержать хотя бы 1 символ (не считая пробелов)
-	trimmedMessage := strings.TrimSpace(req.Text)
+	trimmedMessage := strings.TrimSpace(text)
 	if len(trimmedMessage) == 0 {
 		err := status.Error(codes.InvalidArgument, "Message text must contain at least 1 non-space character")
 		return err
@@ -68,3 +199,567 @@ func (req *SendMessageRequest) Validate() error {
 
 	return nil
 }
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если ID пользователя не указан.
+//   - nil в остальных случаях.
+func (req *GetChatRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.ID == 0 {
+		err := status.Error(codes.InvalidArgument, "ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если ID пользователя не указан.
+//   - error, если Limit отрицательный или больше MaxListMessagesLimit.
+//   - error, если AfterSeq отрицательный.
+//   - nil в остальных случаях.
+func (req *ListMessagesRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.Chat_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// Limit должен быть в пределах от 0 до MaxListMessagesLimit
+	if req.Limit < 0 || req.Limit > MaxListMessagesLimit {
+		err := status.Errorf(codes.InvalidArgument, "Limit must be between 0 and %d", MaxListMessagesLimit)
+		return err
+	}
+
+	// Порядковые номера сообщений не бывают отрицательными
+	if cursor, ok := req.Cursor.(*ListMessagesRequest_AfterSeq); ok && cursor.AfterSeq < 0 {
+		err := status.Error(codes.InvalidArgument, "After seq must not be negative")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если ID пользователя не указан.
+//   - nil в остальных случаях.
+func (req *ConnectChatRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.Chat_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если ID пользователя не указан.
+//   - nil в остальных случаях.
+func (req *RestoreChatRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.ID == 0 {
+		err := status.Error(codes.InvalidArgument, "ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если User_IDs пустой.
+//   - error, если ID пользователя, выполняющего операцию, не указан.
+//   - nil в остальных случаях.
+func (req *AddChatMembersRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.Chat_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID required")
+		return err
+	}
+
+	// User_IDs должен содержать хотя бы 1 айди
+	if len(req.User_IDs) == 0 {
+		err := status.Error(codes.InvalidArgument, "User_IDs must contain at least one ID.")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя, выполняющего операцию
+	if req.Actor_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Actor ID required")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если User_IDs пустой.
+//   - error, если ID пользователя, выполняющего операцию, не указан.
+//   - nil в остальных случаях.
+func (req *RemoveChatMembersRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.Chat_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID required")
+		return err
+	}
+
+	// User_IDs должен содержать хотя бы 1 айди
+	if len(req.User_IDs) == 0 {
+		err := status.Error(codes.InvalidArgument, "User_IDs must contain at least one ID.")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя, выполняющего операцию
+	if req.Actor_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Actor ID required")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если ID пользователя не указан.
+//   - error, если UpdateMask пустой, содержит неизвестные или повторяющиеся поля.
+//   - error, если обновляется ChatName и он пустой либо состоит только из пробелов.
+//   - nil в остальных случаях.
+func (req *UpdateChatRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.ID == 0 {
+		err := status.Error(codes.InvalidArgument, "ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// UpdateMask должен содержать хотя бы 1 поле
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		err := status.Error(codes.InvalidArgument, "Update mask must contain at least one field")
+		return err
+	}
+
+	seen := make(map[string]bool, len(req.UpdateMask.GetPaths()))
+	for _, path := range req.UpdateMask.GetPaths() {
+		// Каждое поле должно встречаться в UpdateMask не более одного раза
+		if seen[path] {
+			err := status.Errorf(codes.InvalidArgument, "Duplicate update mask field: %q", path)
+			return err
+		}
+		seen[path] = true
+
+		switch path {
+		case UpdateChatFieldName:
+			if err := validateChatName(req.ChatName); err != nil {
+				return err
+			}
+		case UpdateChatFieldDescription:
+		default:
+			err := status.Errorf(codes.InvalidArgument, "Unknown update mask field: %q", path)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID пользователя не указан.
+//   - error, если Limit отрицательный или больше MaxListChatsLimit.
+//   - nil в остальных случаях.
+func (req *ListChatsRequest) Validate() error {
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// Limit должен быть в пределах от 0 до MaxListChatsLimit
+	if req.Limit < 0 || req.Limit > MaxListChatsLimit {
+		err := status.Errorf(codes.InvalidArgument, "Limit must be between 0 and %d", MaxListChatsLimit)
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID сообщения не указан.
+//   - error, если ID пользователя не указан.
+//   - error, если Text пустой или состоит только из пробелов.
+//   - nil в остальных случаях.
+func (req *EditMessageRequest) Validate() error {
+	// В запросе должен содержаться ID сообщения
+	if req.Message_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Message ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	return validateMessageText(req.Text)
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID сообщения не указан.
+//   - error, если ID пользователя не указан.
+//   - nil в остальных случаях.
+func (req *DeleteMessageRequest) Validate() error {
+	// В запросе должен содержаться ID сообщения
+	if req.Message_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Message ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата не указан.
+//   - error, если ID пользователя, выполняющего операцию, не указан.
+//   - error, если ID участника не указан.
+//   - error, если Role не CHAT_ROLE_MEMBER и не CHAT_ROLE_ADMIN.
+//   - nil в остальных случаях.
+func (req *SetChatMemberRoleRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.Chat_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя, выполняющего операцию
+	if req.Actor_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Actor ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// Назначить можно только участника или администратора. Передача владения не поддерживается
+	if req.Role != ChatRole_CHAT_ROLE_MEMBER && req.Role != ChatRole_CHAT_ROLE_ADMIN {
+		err := status.Error(codes.InvalidArgument, "Role must be CHAT_ROLE_MEMBER or CHAT_ROLE_ADMIN")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID корневого сообщения не указан.
+//   - error, если ID пользователя не указан.
+//   - error, если Limit отрицательный или больше MaxListThreadLimit.
+//   - nil в остальных случаях.
+func (req *ListThreadRequest) Validate() error {
+	// В запросе должен содержаться ID корневого сообщения
+	if req.RootMessage_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Root message ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// Limit должен быть в пределах от 0 до MaxListThreadLimit
+	if req.Limit < 0 || req.Limit > MaxListThreadLimit {
+		err := status.Errorf(codes.InvalidArgument, "Limit must be between 0 and %d", MaxListThreadLimit)
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID сообщения или ID пользователя не указан.
+//   - error, если Emoji пустой, содержит пробелы или длиннее MaxReactionEmojiLength байт.
+//   - nil в остальных случаях.
+func (req *AddReactionRequest) Validate() error {
+	// В запросе должен содержаться ID сообщения
+	if req.Message_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Message ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	return validateReactionEmoji(req.Emoji)
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID сообщения или ID пользователя не указан.
+//   - error, если Emoji пустой, содержит пробелы или длиннее MaxReactionEmojiLength байт.
+//   - nil в остальных случаях.
+func (req *RemoveReactionRequest) Validate() error {
+	// В запросе должен содержаться ID сообщения
+	if req.Message_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Message ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	return validateReactionEmoji(req.Emoji)
+}
+
+// validateReactionEmoji проверяет реакцию.
+//
+// Возвращает:
+//   - error, если реакция пустая, содержит пробелы, не является корректной UTF-8 строкой
+//     или длиннее MaxReactionEmojiLength байт.
+//   - nil в остальных случаях.
+func validateReactionEmoji(emoji string) error {
+	// Реакция должна быть непустой
+	if len(emoji) == 0 {
+		err := status.Error(codes.InvalidArgument, "Emoji required")
+		return err
+	}
+
+	// Реакция не должна быть длиннее MaxReactionEmojiLength байт
+	if len(emoji) > MaxReactionEmojiLength {
+		err := status.Errorf(codes.InvalidArgument, "Emoji must be at most %d bytes long", MaxReactionEmojiLength)
+		return err
+	}
+
+	// Реакция должна быть корректной UTF-8 строкой без пробелов
+	if !utf8.ValidString(emoji) || strings.IndexFunc(emoji, unicode.IsSpace) >= 0 {
+		err := status.Error(codes.InvalidArgument, "Emoji must be a valid UTF-8 string without spaces")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата, ID пользователя или ID сообщения не указан.
+//   - nil в остальных случаях.
+func (req *MarkReadRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.Chat_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID последнего прочитанного сообщения
+	if req.UpToMessage_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Up to message ID required")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID чата или ID пользователя не указан.
+//   - error, если State не является известным значением TypingState.
+//   - nil в остальных случаях.
+func (req *TypingRequest) Validate() error {
+	// В запросе должен содержаться ID чата
+	if req.Chat_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID required")
+		return err
+	}
+
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// State должен быть одним из известных значений
+	if _, ok := TypingState_name[int32(req.State)]; !ok {
+		err := status.Error(codes.InvalidArgument, "Unknown typing state")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если список пользователей пустой или длиннее MaxGetPresenceUsers.
+//   - error, если какой-либо ID пользователя не указан.
+//   - nil в остальных случаях.
+func (req *GetPresenceRequest) Validate() error {
+	// User_IDs должен содержать хотя бы 1 айди
+	if len(req.User_IDs) == 0 {
+		err := status.Error(codes.InvalidArgument, "User_IDs must contain at least one ID.")
+		return err
+	}
+
+	// Количество пользователей не должно превышать MaxGetPresenceUsers
+	if len(req.User_IDs) > MaxGetPresenceUsers {
+		err := status.Errorf(codes.InvalidArgument, "At most %d user IDs allowed", MaxGetPresenceUsers)
+		return err
+	}
+
+	// Все ID пользователей должны быть указаны
+	for _, userID := range req.User_IDs {
+		if userID == 0 {
+			err := status.Error(codes.InvalidArgument, "User ID must not be zero")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID какого-либо из пользователей не указан.
+//   - error, если ID пользователей совпадают.
+//   - nil в остальных случаях.
+func (req *GetOrCreateDirectChatRequest) Validate() error {
+	// В запросе должны содержаться ID обоих пользователей
+	if req.User_IDA == 0 || req.User_IDB == 0 {
+		err := status.Error(codes.InvalidArgument, "Both user IDs required")
+		return err
+	}
+
+	// Личный чат создается между двумя разными пользователями
+	if req.User_IDA == req.User_IDB {
+		err := status.Error(codes.InvalidArgument, "User IDs must be different")
+		return err
+	}
+
+	return nil
+}
+
+// Validate
+//
+// Возвращает:
+//   - error, если ID пользователя не указан.
+//   - error, если Query пустой, состоит только из пробелов или длиннее MaxSearchQueryLength байт.
+//   - error, если Chat_ID отрицательный.
+//   - error, если Limit отрицательный или больше MaxSearchMessagesLimit.
+//   - nil в остальных случаях.
+func (req *SearchMessagesRequest) Validate() error {
+	// В запросе должен содержаться ID пользователя
+	if req.User_ID == 0 {
+		err := status.Error(codes.InvalidArgument, "User ID required")
+		return err
+	}
+
+	// Query должен содержать хотя бы 1 символ (не считая пробелов)
+	if len(strings.TrimSpace(req.Query)) == 0 {
+		err := status.Error(codes.InvalidArgument, "Query must contain at least 1 non-space character")
+		return err
+	}
+
+	// Query не должен быть длиннее MaxSearchQueryLength байт
+	if len(req.Query) > MaxSearchQueryLength {
+		err := status.Errorf(codes.InvalidArgument, "Query must be at most %d bytes long", MaxSearchQueryLength)
+		return err
+	}
+
+	// Chat_ID может быть не указан (0), но не может быть отрицательным
+	if req.Chat_ID < 0 {
+		err := status.Error(codes.InvalidArgument, "Chat ID must not be negative")
+		return err
+	}
+
+	// Limit должен быть в пределах от 0 до MaxSearchMessagesLimit
+	if req.Limit < 0 || req.Limit > MaxSearchMessagesLimit {
+		err := status.Errorf(codes.InvalidArgument, "Limit must be between 0 and %d", MaxSearchMessagesLimit)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+)
+
+// AddChatMembers добавляет пользователей в чат.
+//
+// Операция идемпотентна: пользователи, уже состоящие в чате, пропускаются.
+// Добавлять участников могут владелец и администраторы чата. Новые участники получают роль member.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID чата и ID добавляемых пользователей.
+//
+// Возвращает:
+//   - *ChatMembersResponse: актуальный список участников чата.
+//   - error: NotFound, если чат не существует, PermissionDenied, если пользователю не разрешено
+//     добавлять участников, FailedPrecondition, если чат личный, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) AddChatMembers(ctx context.Context, req *desc.AddChatMembersRequest) (*desc.ChatMembersResponse, error) {
+	i.log.Info("Method Add-Chat-Members", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Add-Chat-Members.", zap.Error(err))
+		return nil, err
+	}
+
+	userIDs, err := i.chatService.AddMembers(ctx, req.Chat_ID, req.Actor_ID, req.User_IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.ChatMembersResponse{
+		User_IDs: userIDs,
+	}, nil
+}
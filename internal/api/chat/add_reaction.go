@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// AddReaction ставит реакцию пользователя на сообщение.
+//
+// Повторная постановка той же реакции ничего не меняет. Количество различных реакций на сообщение
+// ограничено maxMessageReactionKinds.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID сообщения, ID пользователя и реакцию.
+//
+// Возвращает:
+//   - *MessageReactions: актуальный набор реакций на сообщение.
+//   - error: NotFound, если сообщение (или его чат) не существует, PermissionDenied, если пользователь
+//     не состоит в чате, FailedPrecondition, если сообщение удалено или на нем слишком много
+//     различных реакций, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) AddReaction(ctx context.Context, req *desc.AddReactionRequest) (*desc.MessageReactions, error) {
+	i.log.Info("Method Add-Reaction", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Add-Reaction.", zap.Error(err))
+		return nil, err
+	}
+
+	reactions, err := i.messageService.AddReaction(ctx, req.Message_ID, req.User_ID, req.Emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToMessageReactionsFromService(reactions), nil
+}
@@ -0,0 +1,82 @@
+package chat
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+)
+
+// ConnectChat подключает участника к чату и передает ему события чата в реальном времени.
+//
+// Поток остается открытым, пока клиент не отменит запрос. Каждое сообщение, принятое SendMessage,
+// доставляется всем подключенным участникам чата, в том числе подключенным к другим экземплярам сервера.
+// Пока поток открыт, пользователь считается онлайн, а участники его чатов получают изменения его присутствия.
+// Если пользователя удаляют из чата, поток завершается с ошибкой PermissionDenied, если удаляют сам чат - NotFound.
+//
+// Параметры:
+//   - req: запрос на подключение (ID чата и ID подключающегося пользователя).
+//   - stream: серверный поток событий чата.
+//
+// Возвращает:
+//   - error: NotFound, если чат не существует или был удален, PermissionDenied, если пользователь не состоит в чате
+//     или был удален из него, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) ConnectChat(req *desc.ConnectChatRequest, stream desc.ChatV1_ConnectChatServer) error {
+	i.log.Info("Method Connect-Chat", zap.Any("Input params", req))
+	ctx := stream.Context()
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Connect-Chat.", zap.Error(err))
+		return err
+	}
+
+	// Пока поток открыт, пользователь подписан на события чата и считается онлайн
+	sub, release, err := i.chatService.Connect(ctx, req.Chat_ID, req.User_ID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	for {
+		select {
+		case <-ctx.Done():
+			i.log.Info("Method Connect-Chat. Client disconnected", zap.Int64("chat_id", req.Chat_ID), zap.Int64("user_id", req.User_ID))
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				i.log.Info("Method Connect-Chat. Subscriber is too slow, disconnecting", zap.Int64("chat_id", req.Chat_ID), zap.Int64("user_id", req.User_ID))
+				return status.Error(codes.ResourceExhausted, "Subscriber is too slow to receive chat events")
+			}
+			// Индикаторы набора текста доставляются только в поток Typing
+			if event.GetTyping() != nil {
+				continue
+			}
+			if err = stream.Send(event); err != nil {
+				i.log.Error("Method Connect-Chat. Unable to send event", zap.Error(err))
+				return err
+			}
+			// Удаленный из чата участник получает событие о своем удалении, после чего поток завершается
+			if isRemovedMember(event, req.User_ID) {
+				i.log.Info("Method Connect-Chat. User removed from chat, disconnecting", zap.Int64("chat_id", req.Chat_ID), zap.Int64("user_id", req.User_ID))
+				return status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", req.User_ID, req.Chat_ID)
+			}
+			if event.GetChatDeleted() != nil {
+				i.log.Info("Method Connect-Chat. Chat deleted, disconnecting", zap.Int64("chat_id", req.Chat_ID), zap.Int64("user_id", req.User_ID))
+				return status.Errorf(codes.NotFound, "Chat with ID %d was deleted", req.Chat_ID)
+			}
+		}
+	}
+}
+
+// isRemovedMember сообщает, что событие удаляет пользователя userID из чата.
+func isRemovedMember(event *desc.ChatEvent, userID int64) bool {
+	for _, removedID := range event.GetMembersRemoved().GetUser_IDs() {
+		if removedID == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// CreateChat создает чат.
+//
+// Устанавливает название и описание чата, добавляет пользователей к чату, исходя из переданного массива user_IDs из запроса.
+// Владельцем чата становится owner_ID, либо первый пользователь из user_IDs, если owner_ID не указан.
+// Повторный запрос владельца с тем же ключом идемпотентности возвращает ID созданного ранее чата.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий информацию о создаваемом чате.
+//
+// Возвращает:
+//   - *CreateChatResponse: структура с ID созданного чата.
+//   - error: если что-то пошло не так.
+func (i *Implementation) CreateChat(ctx context.Context, req *desc.CreateChatRequest) (*desc.CreateChatResponse, error) {
+	i.log.Info("Method Create-Chat", zap.Any("input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Create-Chat", zap.Error(err))
+		return nil, err
+	}
+
+	chatID, err := i.chatService.Create(ctx, converter.ToChatInfoFromDesc(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.CreateChatResponse{
+		ID: chatID,
+	}, nil
+}
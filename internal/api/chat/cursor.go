@@ -0,0 +1,77 @@
+package chat
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// encodeCursor кодирует позицию записи в выдаче, упорядоченной по паре (время, id),
+// в непрозрачную для клиента строку.
+//
+// Курсор хранит обе величины: это позволяет продолжить выборку без дополнительного запроса к БД.
+// Используется для пагинации сообщений (created_at, id) и чатов (время последней активности, id).
+func encodeCursor(at time.Time, id int64) string {
+	raw := fmt.Sprintf("%d:%d", at.UnixNano(), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor декодирует строку, полученную из encodeCursor.
+//
+// Возвращает:
+//   - *model.PageCursor: позиция записи.
+//   - error: если строка не является корректным курсором.
+func decodeCursor(cursor string) (*model.PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var nanos, id int64
+	if _, err = fmt.Sscanf(string(raw), "%d:%d", &nanos, &id); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &model.PageCursor{
+		At: time.Unix(0, nanos).UTC(),
+		ID: id,
+	}, nil
+}
+
+// encodeRankCursor кодирует позицию записи в выдаче, упорядоченной по паре (ранг, id),
+// в непрозрачную для клиента строку.
+//
+// Используется для пагинации результатов поиска сообщений.
+// Ранг хранится побитово, чтобы при продолжении выборки сравнение с рангом из БД было точным.
+func encodeRankCursor(rank float32, id int64) string {
+	raw := fmt.Sprintf("r%d:%d", math.Float32bits(rank), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeRankCursor декодирует строку, полученную из encodeRankCursor.
+//
+// Возвращает:
+//   - *model.RankCursor: позиция записи.
+//   - error: если строка не является корректным курсором.
+func decodeRankCursor(cursor string) (*model.RankCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var (
+		bits uint32
+		id   int64
+	)
+	if _, err = fmt.Sscanf(string(raw), "r%d:%d", &bits, &id); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &model.RankCursor{
+		Rank: math.Float32frombits(bits),
+		ID:   id,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+)
+
+// DeleteChat удаляет чат.
+//
+// Чат удаляется мягко: ему проставляется deleted_at, после чего он скрывается из всех операций чтения и записи.
+// В течение grace-периода чат можно восстановить через RestoreChat, после его окончания чат вместе с участниками
+// и сообщениями окончательно удаляется фоновой задачей (см. PurgeDeleted сервиса чатов).
+// Групповой чат может удалить только владелец, личный - любой из двух его участников.
+// Потоки ConnectChat и Typing, открытые к чату, завершаются с ошибкой NotFound.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос для удаления чата (ID удаляемого чата и ID удаляющего пользователя).
+//
+// Возвращает:
+//   - *emptypb.Empty: пустая структура, в случае успешного удаления.
+//   - error: NotFound, если чат не существует или уже удален, PermissionDenied, если пользователю
+//     не разрешено удалять чат, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) DeleteChat(ctx context.Context, req *desc.DeleteChatRequest) (*emptypb.Empty, error) {
+	i.log.Info("Method Delete-Chat", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Delete-Chat.", zap.Error(err))
+		return nil, err
+	}
+
+	err := i.chatService.Delete(ctx, req.ID, req.User_ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
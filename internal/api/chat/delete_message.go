@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// DeleteMessage удаляет сообщение.
+//
+// Строка сообщения сохраняется для порядка истории и аудита, но его текст, история правок и реакции удаляются,
+// а сообщению проставляется deleted_at. Подключенные участники чата получают событие message_deleted.
+// Удалить сообщение может его автор, а также владелец и администраторы чата.
+// Повторное удаление возвращает уже удаленное сообщение.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID сообщения и ID удаляющего пользователя.
+//
+// Возвращает:
+//   - *Message: удаленное сообщение без текста.
+//   - error: NotFound, если сообщение (или его чат) не существует, PermissionDenied, если пользователю
+//     не разрешено удалять сообщение, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) DeleteMessage(ctx context.Context, req *desc.DeleteMessageRequest) (*desc.Message, error) {
+	i.log.Info("Method Delete-Message", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Delete-Message.", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := i.messageService.Delete(ctx, req.Message_ID, req.User_ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToMessageFromService(message), nil
+}
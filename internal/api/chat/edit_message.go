@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// EditMessage изменяет текст сообщения.
+//
+// Редактировать сообщение может только его автор. Предыдущий текст сохраняется в историю
+// правок (chat_message_edits), подключенные участники чата получают событие message_edited.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID сообщения, ID редактирующего пользователя и новый текст.
+//
+// Возвращает:
+//   - *Message: отредактированное сообщение.
+//   - error: NotFound, если сообщение (или его чат) не существует, PermissionDenied, если пользователь
+//     не является автором сообщения, FailedPrecondition, если сообщение удалено,
+//     либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) EditMessage(ctx context.Context, req *desc.EditMessageRequest) (*desc.Message, error) {
+	i.log.Info("Method Edit-Message", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Edit-Message.", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := i.messageService.Edit(ctx, req.Message_ID, req.User_ID, req.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToMessageFromService(message), nil
+}
@@ -0,0 +1,37 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// GetChat возвращает информацию о чате и список его участников.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос на получение чата (ID чата и ID запрашивающего пользователя).
+//
+// Возвращает:
+//   - *GetChatResponse: название, описание чата и его участники с ролями.
+//   - error: NotFound, если чат с указанным ID не существует, PermissionDenied, если пользователь
+//     не состоит в чате, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) GetChat(ctx context.Context, req *desc.GetChatRequest) (*desc.GetChatResponse, error) {
+	i.log.Info("Method Get-Chat", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Get-Chat.", zap.Error(err))
+		return nil, err
+	}
+
+	chat, members, err := i.chatService.Get(ctx, req.ID, req.User_ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToGetChatResponseFromService(chat, members), nil
+}
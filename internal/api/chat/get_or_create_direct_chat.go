@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+)
+
+// GetOrCreateDirectChat возвращает личный чат двух пользователей, создавая его при необходимости.
+//
+// У пары пользователей может быть только один неудаленный личный чат: это гарантирует уникальный индекс
+// по нормализованной паре ID, поэтому параллельные запросы для одной пары вернут один и тот же чат.
+// Личный чат не имеет названия, оба пользователя становятся его обычными участниками.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID двух пользователей.
+//
+// Возвращает:
+//   - *GetOrCreateDirectChatResponse: ID чата и признак того, что чат был создан.
+//   - error: если что-то пошло не так.
+func (i *Implementation) GetOrCreateDirectChat(ctx context.Context, req *desc.GetOrCreateDirectChatRequest) (*desc.GetOrCreateDirectChatResponse, error) {
+	i.log.Info("Method Get-Or-Create-Direct-Chat", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Get-Or-Create-Direct-Chat.", zap.Error(err))
+		return nil, err
+	}
+
+	chatID, created, err := i.chatService.GetOrCreateDirect(ctx, req.User_IDA, req.User_IDB)
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.GetOrCreateDirectChatResponse{
+		ID:      chatID,
+		Created: created,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// GetPresence возвращает присутствие пользователей.
+//
+// Пользователь онлайн, пока у него есть активное потоковое подключение (ConnectChat или Typing),
+// а также в течение grace-периода после отключения.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID пользователей.
+//
+// Возвращает:
+//   - *GetPresenceResponse: присутствие пользователей в порядке запроса.
+//   - error: если что-то пошло не так.
+func (i *Implementation) GetPresence(ctx context.Context, req *desc.GetPresenceRequest) (*desc.GetPresenceResponse, error) {
+	i.log.Info("Method Get-Presence", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Get-Presence.", zap.Error(err))
+		return nil, err
+	}
+
+	presences, err := i.presenceService.Get(ctx, req.User_IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.GetPresenceResponse{
+		Presences: converter.ToUserPresencesFromService(presences),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// ListChats возвращает чаты, в которых состоит пользователь.
+//
+// Чаты упорядочены по времени последней активности: времени последнего сообщения,
+// либо времени создания чата, если сообщений нет. Для каждого чата возвращается количество
+// участников, превью последнего сообщения и количество непрочитанных пользователем сообщений.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID пользователя и параметры пагинации.
+//
+// Возвращает:
+//   - *ListChatsResponse: страница чатов и курсор следующей страницы.
+//   - error: если что-то пошло не так.
+func (i *Implementation) ListChats(ctx context.Context, req *desc.ListChatsRequest) (*desc.ListChatsResponse, error) {
+	i.log.Info("Method List-Chats", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method List-Chats.", zap.Error(err))
+		return nil, err
+	}
+
+	var after *model.PageCursor
+	if req.Cursor != "" {
+		c, err := decodeCursor(req.Cursor)
+		if err != nil {
+			i.log.Info("Method List-Chats. Invalid cursor", zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, "Invalid cursor")
+		}
+		after = c
+	}
+
+	chats, hasMore, err := i.chatService.List(ctx, req.User_ID, req.Limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &desc.ListChatsResponse{
+		Chats: converter.ToChatSummariesFromService(chats),
+	}
+	if hasMore {
+		last := chats[len(chats)-1]
+		resp.NextCursor = encodeCursor(last.LastActivityAt, last.ID)
+	}
+
+	return resp, nil
+}
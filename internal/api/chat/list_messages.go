@@ -0,0 +1,81 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// ListMessages возвращает страницу истории сообщений чата.
+//
+// Сообщения упорядочены по времени создания. Пагинация выполняется с помощью непрозрачных курсоров:
+// курсор before возвращает сообщения, отправленные раньше указанного, курсор after - позже указанного,
+// after_seq - сообщения с порядковым номером больше указанного.
+// Если курсор не указан, возвращаются последние сообщения чата. Сообщения возвращаются вместе с реакциями
+// и списком прочитавших их участников.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос на получение истории сообщений.
+//
+// Возвращает:
+//   - *ListMessagesResponse: страница сообщений и курсоры для загрузки соседних страниц.
+//   - error: NotFound, если чат не существует, PermissionDenied, если пользователь не состоит в чате,
+//     либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) ListMessages(ctx context.Context, req *desc.ListMessagesRequest) (*desc.ListMessagesResponse, error) {
+	i.log.Info("Method List-Messages", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method List-Messages.", zap.Error(err))
+		return nil, err
+	}
+
+	params := &model.ListMessagesParams{
+		ChatID: req.Chat_ID,
+		UserID: req.User_ID,
+		Limit:  req.Limit,
+	}
+	switch cursor := req.Cursor.(type) {
+	case *desc.ListMessagesRequest_Before:
+		c, err := decodeCursor(cursor.Before)
+		if err != nil {
+			i.log.Info("Method List-Messages. Invalid cursor", zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, "Invalid before cursor")
+		}
+		params.Before = c
+	case *desc.ListMessagesRequest_After:
+		c, err := decodeCursor(cursor.After)
+		if err != nil {
+			i.log.Info("Method List-Messages. Invalid cursor", zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, "Invalid after cursor")
+		}
+		params.After = c
+	case *desc.ListMessagesRequest_AfterSeq:
+		afterSeq := cursor.AfterSeq
+		params.AfterSeq = &afterSeq
+	}
+
+	messages, hasMore, err := i.messageService.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &desc.ListMessagesResponse{
+		Messages: converter.ToMessagesFromService(messages),
+		HasMore:  hasMore,
+	}
+	if len(messages) > 0 {
+		first, last := messages[0], messages[len(messages)-1]
+		resp.BeforeCursor = encodeCursor(first.CreatedAt, first.ID)
+		resp.AfterCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,62 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// ListThread возвращает тред сообщения: само сообщение и страницу ответов на него.
+//
+// В тред входят прямые ответы на корневое сообщение, упорядоченные по времени создания
+// (от старых к новым). Пагинация выполняется с помощью непрозрачного курсора next_cursor.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID корневого сообщения, ID пользователя, размер страницы и курсор.
+//
+// Возвращает:
+//   - *ListThreadResponse: корневое сообщение, страница ответов и курсор следующей страницы.
+//   - error: NotFound, если сообщение не существует или его чат удален, PermissionDenied, если пользователь
+//     не состоит в чате сообщения, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) ListThread(ctx context.Context, req *desc.ListThreadRequest) (*desc.ListThreadResponse, error) {
+	i.log.Info("Method List-Thread", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method List-Thread.", zap.Error(err))
+		return nil, err
+	}
+
+	var after *model.PageCursor
+	if req.Cursor != "" {
+		c, err := decodeCursor(req.Cursor)
+		if err != nil {
+			i.log.Info("Method List-Thread. Invalid cursor", zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, "Invalid cursor")
+		}
+		after = c
+	}
+
+	root, replies, hasMore, err := i.messageService.ListThread(ctx, req.RootMessage_ID, req.User_ID, req.Limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &desc.ListThreadResponse{
+		Root:    converter.ToMessageFromService(root),
+		Replies: converter.ToMessagesFromService(replies),
+	}
+	if hasMore {
+		last := replies[len(replies)-1]
+		resp.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// MarkRead отмечает сообщения чата прочитанными пользователем.
+//
+// Для каждого участника хранится указатель на последнее прочитанное сообщение. Указатель только
+// продвигается вперед: отметка более раннего сообщения не меняет его.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID чата, ID пользователя и ID последнего прочитанного сообщения.
+//
+// Возвращает:
+//   - *ChatReadState: актуальный указатель прочтения и количество непрочитанных сообщений.
+//   - error: NotFound, если сообщение (или его чат) не существует, InvalidArgument, если сообщение
+//     принадлежит другому чату, PermissionDenied, если пользователь не состоит в чате,
+//     либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) MarkRead(ctx context.Context, req *desc.MarkReadRequest) (*desc.ChatReadState, error) {
+	i.log.Info("Method Mark-Read", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Mark-Read.", zap.Error(err))
+		return nil, err
+	}
+
+	state, err := i.messageService.MarkRead(ctx, req.Chat_ID, req.User_ID, req.UpToMessage_ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToReadStateFromService(state), nil
+}
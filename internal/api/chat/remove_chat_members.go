@@ -0,0 +1,43 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+)
+
+// RemoveChatMembers удаляет пользователей из чата.
+//
+// Операция идемпотентна: пользователи, не состоящие в чате, пропускаются.
+// Любой участник может удалить себя (выйти из чата). Администраторы могут удалять участников,
+// владелец - участников и администраторов. Владельца удалить из чата нельзя.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID чата и ID удаляемых пользователей.
+//
+// Возвращает:
+//   - *ChatMembersResponse: актуальный список участников чата.
+//   - error: NotFound, если чат не существует, PermissionDenied, если пользователю не разрешено
+//     удалять кого-либо из участников, FailedPrecondition при попытке удалить владельца
+//     или если чат личный, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) RemoveChatMembers(ctx context.Context, req *desc.RemoveChatMembersRequest) (*desc.ChatMembersResponse, error) {
+	i.log.Info("Method Remove-Chat-Members", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Remove-Chat-Members.", zap.Error(err))
+		return nil, err
+	}
+
+	userIDs, err := i.chatService.RemoveMembers(ctx, req.Chat_ID, req.Actor_ID, req.User_IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.ChatMembersResponse{
+		User_IDs: userIDs,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// RemoveReaction снимает реакцию пользователя с сообщения.
+//
+// Снятие отсутствующей реакции ничего не меняет.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID сообщения, ID пользователя и реакцию.
+//
+// Возвращает:
+//   - *MessageReactions: актуальный набор реакций на сообщение.
+//   - error: NotFound, если сообщение (или его чат) не существует, PermissionDenied, если пользователь
+//     не состоит в чате, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) RemoveReaction(ctx context.Context, req *desc.RemoveReactionRequest) (*desc.MessageReactions, error) {
+	i.log.Info("Method Remove-Reaction", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Remove-Reaction.", zap.Error(err))
+		return nil, err
+	}
+
+	reactions, err := i.messageService.RemoveReaction(ctx, req.Message_ID, req.User_ID, req.Emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToMessageReactionsFromService(reactions), nil
+}
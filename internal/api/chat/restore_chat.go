@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+)
+
+// RestoreChat восстанавливает удаленный чат.
+//
+// Восстановить можно только чат, удаленный не раньше, чем grace-период назад. Права на восстановление
+// те же, что и на удаление. Личный чат нельзя восстановить, если у пары пользователей уже есть другой личный чат.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос для восстановления чата (ID чата и ID восстанавливающего пользователя).
+//
+// Возвращает:
+//   - *emptypb.Empty: пустая структура, в случае успешного восстановления.
+//   - error: PermissionDenied, если пользователю не разрешено восстанавливать чат, NotFound, если удаленный чат
+//     не найден или grace-период истек, FailedPrecondition, если у пары пользователей личного чата
+//     уже есть другой личный чат, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) RestoreChat(ctx context.Context, req *desc.RestoreChatRequest) (*emptypb.Empty, error) {
+	i.log.Info("Method Restore-Chat", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Restore-Chat.", zap.Error(err))
+		return nil, err
+	}
+
+	err := i.chatService.Restore(ctx, req.ID, req.User_ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,67 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// SearchMessages выполняет полнотекстовый поиск сообщений.
+//
+// Поиск выполняется по неудаленным сообщениям чатов, в которых состоит пользователь: по одному чату,
+// если указан Chat_ID, либо по всем чатам пользователя. Результаты упорядочены по убыванию релевантности
+// и содержат фрагменты текста с выделенными совпадениями. Пагинация выполняется с помощью курсора next_cursor.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID пользователя, поисковый запрос, область поиска и параметры пагинации.
+//
+// Возвращает:
+//   - *SearchMessagesResponse: страница найденных сообщений и курсор следующей страницы.
+//   - error: NotFound, если указанный чат не существует, PermissionDenied, если пользователь в нем не состоит,
+//     либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) SearchMessages(ctx context.Context, req *desc.SearchMessagesRequest) (*desc.SearchMessagesResponse, error) {
+	i.log.Info("Method Search-Messages", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Search-Messages.", zap.Error(err))
+		return nil, err
+	}
+
+	params := &model.SearchParams{
+		UserID: req.User_ID,
+		Query:  req.Query,
+		ChatID: req.Chat_ID,
+		Limit:  req.Limit,
+	}
+	if req.Cursor != "" {
+		c, err := decodeRankCursor(req.Cursor)
+		if err != nil {
+			i.log.Info("Method Search-Messages. Invalid cursor", zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument, "Invalid cursor")
+		}
+		params.After = c
+	}
+
+	hits, hasMore, err := i.messageService.Search(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &desc.SearchMessagesResponse{
+		Hits: converter.ToSearchHitsFromService(hits),
+	}
+	if hasMore {
+		last := hits[len(hits)-1]
+		resp.NextCursor = encodeRankCursor(last.Rank, last.Message.ID)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,51 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// SendMessage отправляет сообщение от пользователя в выбранный чат.
+//
+// Отправитель должен состоять в чате. Если указан ReplyToMessage_ID, сообщение становится ответом
+// на неудаленное сообщение того же чата. Проверки выполняются в одной транзакции со вставкой сообщения.
+//
+// Время создания сообщения назначает сервер, время по часам клиента (Timestamp) сохраняется отдельно.
+// В той же транзакции сообщению выделяется следующий порядковый номер в чате.
+// Повторный запрос с тем же ключом идемпотентности возвращает отправленное ранее сообщение.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос на отправку сообщения в чат.
+//
+// Возвращает:
+//   - *SendMessageResponse: ID созданного сообщения, время его создания и порядковый номер в чате.
+//   - error: NotFound, если чат или сообщение, на которое дан ответ, не существует,
+//     PermissionDenied, если отправитель не состоит в чате, InvalidArgument, если сообщение,
+//     на которое дан ответ, принадлежит другому чату, FailedPrecondition, если оно удалено,
+//     либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) SendMessage(ctx context.Context, req *desc.SendMessageRequest) (*desc.SendMessageResponse, error) {
+	i.log.Info("Method Send-Message", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Send-Message.", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := i.messageService.Send(ctx, converter.ToMessageInfoFromDesc(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &desc.SendMessageResponse{
+		ID:        message.ID,
+		CreatedAt: timestamppb.New(message.CreatedAt),
+		Seq:       message.Seq,
+	}, nil
+}
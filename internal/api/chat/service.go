@@ -0,0 +1,41 @@
+package chat
+
+import (
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// Implementation - реализация gRPC API чатов (ChatV1).
+//
+// Обработчики валидируют запрос, преобразуют его в модели сервисного слоя и вызывают сервисы.
+// Ошибки сервисов уже содержат gRPC-код и возвращаются клиенту как есть.
+type Implementation struct {
+	desc.UnimplementedChatV1Server
+	chatService     service.ChatService
+	messageService  service.MessageService
+	presenceService service.PresenceService
+	log             *zap.Logger
+}
+
+// NewImplementation создает реализацию gRPC API чатов.
+//
+// Параметры:
+//   - chatService: сервис чатов и их участников.
+//   - messageService: сервис сообщений.
+//   - presenceService: сервис присутствия пользователей.
+//   - logger: логгер.
+func NewImplementation(
+	chatService service.ChatService,
+	messageService service.MessageService,
+	presenceService service.PresenceService,
+	logger *zap.Logger,
+) *Implementation {
+	return &Implementation{
+		chatService:     chatService,
+		messageService:  messageService,
+		presenceService: presenceService,
+		log:             logger,
+	}
+}
@@ -0,0 +1,40 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// SetChatMemberRole изменяет роль участника чата.
+//
+// Назначать и снимать администраторов может только владелец чата. Роль владельца изменить нельзя.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID чата, ID владельца, ID участника и новую роль.
+//
+// Возвращает:
+//   - *ChatMember: участник с новой ролью.
+//   - error: NotFound, если чат или участник не найден, PermissionDenied, если пользователь не является
+//     владельцем чата, FailedPrecondition при попытке изменить роль владельца,
+//     либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) SetChatMemberRole(ctx context.Context, req *desc.SetChatMemberRoleRequest) (*desc.ChatMember, error) {
+	i.log.Info("Method Set-Chat-Member-Role", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Set-Chat-Member-Role.", zap.Error(err))
+		return nil, err
+	}
+
+	member, err := i.chatService.SetMemberRole(ctx, req.Chat_ID, req.Actor_ID, req.User_ID, converter.ToRoleFromDesc(req.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToChatMemberFromService(member), nil
+}
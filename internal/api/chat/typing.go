@@ -0,0 +1,172 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+)
+
+// typingExpiry - время, через которое сервер гасит индикатор набора текста,
+// если клиент не повторил TYPING_STATE_STARTED
+const typingExpiry = 6 * time.Second
+
+// Typing передает индикаторы набора текста между участниками чата.
+//
+// Клиент отправляет в поток изменения своего состояния, а сервер пересылает их остальным участникам чата,
+// подключенным к Typing, в том числе через другие экземпляры сервера. События не сохраняются в БД.
+// Если клиент не повторил TYPING_STATE_STARTED в течение typingExpiry или отключился во время набора текста,
+// сервер сам рассылает TYPING_STATE_STOPPED. Если пользователя удаляют из чата, поток завершается
+// с ошибкой PermissionDenied, если удаляют сам чат - NotFound.
+//
+// Параметры:
+//   - stream: двунаправленный поток. Первое сообщение клиента привязывает поток к чату и пользователю.
+//
+// Возвращает:
+//   - error: NotFound, если чат не существует или был удален, PermissionDenied, если пользователь не состоит в чате
+//     или был удален из него, InvalidArgument, если сообщение клиента содержит другой чат или пользователя,
+//     либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) Typing(stream desc.ChatV1_TypingServer) error {
+	ctx := stream.Context()
+
+	// Первое сообщение привязывает поток к чату и пользователю
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		i.log.Error("Method Typing. Unable to receive request", zap.Error(err))
+		return err
+	}
+	i.log.Info("Method Typing", zap.Any("Input params", first))
+
+	// Валидация полей запроса
+	if err = first.Validate(); err != nil {
+		i.log.Error("Method Typing.", zap.Error(err))
+		return err
+	}
+	chatID, userID := first.Chat_ID, first.User_ID
+
+	// Пока поток открыт, пользователь подписан на события чата и считается онлайн
+	sub, release, err := i.chatService.Connect(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Recv блокируется до получения сообщения, поэтому читаем запросы клиента в отдельной горутине.
+	// Горутина завершится вместе с потоком: после выхода из обработчика Recv вернет ошибку
+	requests := make(chan *desc.TypingRequest)
+	recvErrs := make(chan error, 1)
+	go func() {
+		for {
+			req, recvErr := stream.Recv()
+			if recvErr != nil {
+				recvErrs <- recvErr
+				return
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		typing  bool
+		expired <-chan time.Time
+	)
+	// setTyping рассылает участникам чата изменение состояния пользователя.
+	// Повторный TYPING_STATE_STARTED только продлевает индикатор
+	setTyping := func(notifyCtx context.Context, state desc.TypingState) error {
+		switch state {
+		case desc.TypingState_TYPING_STATE_STARTED:
+			expired = time.After(typingExpiry)
+		case desc.TypingState_TYPING_STATE_STOPPED:
+			expired = nil
+		default:
+			return nil
+		}
+
+		started := state == desc.TypingState_TYPING_STATE_STARTED
+		if started == typing {
+			return nil
+		}
+		typing = started
+
+		return i.chatService.SetTyping(notifyCtx, chatID, userID, started)
+	}
+	// Если клиент отключился во время набора текста, гасим индикатор у остальных участников.
+	// Контекст потока к этому моменту может быть отменен, поэтому используем отдельный
+	defer func() {
+		if !typing {
+			return
+		}
+		stopCtx, cancel := context.WithTimeout(context.Background(), typingExpiry)
+		defer cancel()
+		_ = setTyping(stopCtx, desc.TypingState_TYPING_STATE_STOPPED)
+	}()
+
+	if err = setTyping(ctx, first.State); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			i.log.Info("Method Typing. Client disconnected", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+			return nil
+		case err = <-recvErrs:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			i.log.Error("Method Typing. Unable to receive request", zap.Error(err))
+			return err
+		case req := <-requests:
+			if err = req.Validate(); err != nil {
+				i.log.Error("Method Typing.", zap.Error(err))
+				return err
+			}
+			if req.Chat_ID != chatID || req.User_ID != userID {
+				i.log.Info("Method Typing. Request for another chat or user", zap.Any("Input params", req))
+				return status.Error(codes.InvalidArgument, "Chat ID and user ID must not change within stream")
+			}
+			if err = setTyping(ctx, req.State); err != nil {
+				return err
+			}
+		case <-expired:
+			if err = setTyping(ctx, desc.TypingState_TYPING_STATE_STOPPED); err != nil {
+				return err
+			}
+		case event, ok := <-sub.Events():
+			if !ok {
+				i.log.Info("Method Typing. Subscriber is too slow, disconnecting", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+				return status.Error(codes.ResourceExhausted, "Subscriber is too slow to receive chat events")
+			}
+			if isRemovedMember(event, userID) {
+				i.log.Info("Method Typing. User removed from chat, disconnecting", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+				return status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", userID, chatID)
+			}
+			if event.GetChatDeleted() != nil {
+				i.log.Info("Method Typing. Chat deleted, disconnecting", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+				return status.Errorf(codes.NotFound, "Chat with ID %d was deleted", chatID)
+			}
+			// Пересылаем только индикаторы других участников
+			typingEvent := event.GetTyping()
+			if typingEvent == nil || typingEvent.User_ID == userID {
+				continue
+			}
+			if err = stream.Send(typingEvent); err != nil {
+				i.log.Error("Method Typing. Unable to send event", zap.Error(err))
+				return err
+			}
+		}
+	}
+}
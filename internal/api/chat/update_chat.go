@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/converter"
+)
+
+// UpdateChat обновляет название и/или описание чата.
+//
+// Обновляются только поля, перечисленные в update_mask. Описание чата очищается,
+// если поле chat_description указано в update_mask, но не заполнено.
+// Изменять чат могут владелец и администраторы.
+//
+// Параметры:
+//   - ctx: контекст выполнения операции.
+//   - req: запрос, содержащий ID чата, ID изменяющего пользователя, новые значения полей и маску обновляемых полей.
+//
+// Возвращает:
+//   - *emptypb.Empty: пустая структура, в случае успешного обновления.
+//   - error: NotFound, если чат не существует, PermissionDenied, если пользователь не является
+//     владельцем или администратором чата, либо другая ошибка, если что-то пошло не так.
+func (i *Implementation) UpdateChat(ctx context.Context, req *desc.UpdateChatRequest) (*emptypb.Empty, error) {
+	i.log.Info("Method Update-Chat", zap.Any("Input params", req))
+
+	// Валидация полей запроса
+	if err := req.Validate(); err != nil {
+		i.log.Error("Method Update-Chat.", zap.Error(err))
+		return nil, err
+	}
+
+	err := i.chatService.Update(ctx, req.ID, req.User_ID, converter.ToChatUpdateFromDesc(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
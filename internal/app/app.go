@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	config "github.com/anton0701/chat-server/config"
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/events"
+)
+
+const grpcChatAPIDesc = "Chat-API-v1"
+
+// App - приложение chat-server: gRPC сервер и фоновые задачи.
+type App struct {
+	configPath      string
+	log             *zap.Logger
+	serviceProvider *serviceProvider
+	grpcServer      *grpc.Server
+}
+
+// NewApp создает приложение и инициализирует его зависимости.
+//
+// Параметры:
+//   - ctx: контекст инициализации.
+//   - configPath: путь к файлу с переменными окружения.
+//
+// Возвращает:
+//   - *App: готовое к запуску приложение.
+//   - error: если не удалось инициализировать зависимости.
+func NewApp(ctx context.Context, configPath string) (*App, error) {
+	a := &App{
+		configPath: configPath,
+	}
+
+	err := a.initDeps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Run запускает фоновые задачи и gRPC сервер. Блокируется до остановки сервера.
+func (a *App) Run(ctx context.Context) error {
+	// Слушаем события чатов от всех экземпляров сервера и передаем их локальным подписчикам
+	go events.NewListener(a.serviceProvider.Pool(ctx), a.serviceProvider.Hub(), a.serviceProvider.MessageRepository(ctx), a.log).Run(ctx)
+
+	// Окончательно удаляем чаты, grace-период которых истек
+	go runPeriodically(ctx, a.serviceProvider.ChatConfig().PurgeInterval(), a.serviceProvider.ChatService(ctx).PurgeDeleted)
+
+	// Удаляем устаревшие подключения пользователей
+	go runPeriodically(ctx, a.serviceProvider.ChatConfig().PresenceHeartbeatInterval(), a.serviceProvider.PresenceService(ctx).ExpireConnections)
+
+	return a.runGRPCServer()
+}
+
+func (a *App) initDeps(ctx context.Context) error {
+	inits := []func(context.Context) error{
+		a.initLogger,
+		a.initConfig,
+		a.initServiceProvider,
+		a.initGRPCServer,
+	}
+
+	for _, f := range inits {
+		err := f(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *App) initLogger(_ context.Context) error {
+	zapConfig := zap.NewProductionConfig()
+	zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	logger, err := zapConfig.Build()
+	if err != nil {
+		return err
+	}
+
+	a.log = logger.With(zap.String("API", grpcChatAPIDesc))
+	return nil
+}
+
+// initConfig считывает переменные окружения (env vars)
+func (a *App) initConfig(_ context.Context) error {
+	err := config.Load(a.configPath)
+	if err != nil {
+		a.log.Error("Unable to init config", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (a *App) initServiceProvider(_ context.Context) error {
+	a.serviceProvider = newServiceProvider(a.log)
+	return nil
+}
+
+func (a *App) initGRPCServer(ctx context.Context) error {
+	a.grpcServer = grpc.NewServer()
+	reflection.Register(a.grpcServer)
+
+	desc.RegisterChatV1Server(a.grpcServer, a.serviceProvider.ChatImpl(ctx))
+
+	return nil
+}
+
+func (a *App) runGRPCServer() error {
+	lis, err := net.Listen("tcp", a.serviceProvider.GRPCConfig().Address())
+	if err != nil {
+		a.log.Error("Failed to listen", zap.Error(err))
+		return err
+	}
+
+	a.log.Info("Server listening at", zap.Any("Address", lis.Addr()))
+
+	err = a.grpcServer.Serve(lis)
+	if err != nil {
+		a.log.Error("Failed to serve", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// runPeriodically выполняет job сразу и далее с периодом interval до отмены ctx.
+func runPeriodically(ctx context.Context, interval time.Duration, job func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+
+	env "github.com/anton0701/chat-server/config/env"
+	chatAPI "github.com/anton0701/chat-server/internal/api/chat"
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/client/db/pg"
+	"github.com/anton0701/chat-server/internal/client/db/transaction"
+	"github.com/anton0701/chat-server/internal/events"
+	"github.com/anton0701/chat-server/internal/repository"
+	chatRepository "github.com/anton0701/chat-server/internal/repository/chat"
+	connectionRepository "github.com/anton0701/chat-server/internal/repository/connection"
+	eventRepository "github.com/anton0701/chat-server/internal/repository/event"
+	memberRepository "github.com/anton0701/chat-server/internal/repository/member"
+	messageRepository "github.com/anton0701/chat-server/internal/repository/message"
+	reactionRepository "github.com/anton0701/chat-server/internal/repository/reaction"
+	"github.com/anton0701/chat-server/internal/service"
+	chatService "github.com/anton0701/chat-server/internal/service/chat"
+	messageService "github.com/anton0701/chat-server/internal/service/message"
+	presenceService "github.com/anton0701/chat-server/internal/service/presence"
+)
+
+// serviceProvider - DI-контейнер приложения.
+//
+// Зависимости создаются лениво при первом обращении и далее переиспользуются.
+// Ошибки инициализации фатальны: без них сервер не может работать.
+type serviceProvider struct {
+	log *zap.Logger
+
+	grpcConfig env.GRPCConfig
+	pgConfig   env.PGConfig
+	chatConfig env.ChatConfig
+
+	pool      *pgxpool.Pool
+	dbClient  db.Client
+	txManager db.TxManager
+	hub       *events.Hub
+
+	chatRepository       repository.ChatRepository
+	memberRepository     repository.MemberRepository
+	messageRepository    repository.MessageRepository
+	reactionRepository   repository.ReactionRepository
+	connectionRepository repository.ConnectionRepository
+	eventRepository      repository.EventRepository
+
+	chatService     service.ChatService
+	messageService  service.MessageService
+	presenceService service.PresenceService
+
+	chatImpl *chatAPI.Implementation
+}
+
+func newServiceProvider(logger *zap.Logger) *serviceProvider {
+	return &serviceProvider{
+		log: logger,
+	}
+}
+
+// GRPCConfig возвращает конфиг gRPC сервера.
+func (s *serviceProvider) GRPCConfig() env.GRPCConfig {
+	if s.grpcConfig == nil {
+		cfg, err := env.NewGRPCConfig()
+		if err != nil {
+			s.log.Fatal("Unable to get grpc config", zap.Error(err))
+		}
+
+		s.grpcConfig = cfg
+	}
+
+	return s.grpcConfig
+}
+
+// PGConfig возвращает конфиг подключения к Postgres.
+func (s *serviceProvider) PGConfig() env.PGConfig {
+	if s.pgConfig == nil {
+		cfg, err := env.NewPGConfig()
+		if err != nil {
+			s.log.Fatal("Unable to get postgres config", zap.Error(err))
+		}
+
+		s.pgConfig = cfg
+	}
+
+	return s.pgConfig
+}
+
+// ChatConfig возвращает настройки чатов.
+func (s *serviceProvider) ChatConfig() env.ChatConfig {
+	if s.chatConfig == nil {
+		cfg, err := env.NewChatConfig()
+		if err != nil {
+			s.log.Fatal("Unable to get chat config", zap.Error(err))
+		}
+
+		s.chatConfig = cfg
+	}
+
+	return s.chatConfig
+}
+
+// Pool возвращает пул подключений к Postgres.
+func (s *serviceProvider) Pool(ctx context.Context) *pgxpool.Pool {
+	if s.pool == nil {
+		pool, err := pgxpool.Connect(ctx, s.PGConfig().DSN())
+		if err != nil {
+			s.log.Panic("Unable to connect to db", zap.Error(err))
+		}
+
+		s.pool = pool
+	}
+
+	return s.pool
+}
+
+// DBClient возвращает клиент БД, выполняющий запросы в транзакции из контекста.
+func (s *serviceProvider) DBClient(ctx context.Context) db.Client {
+	if s.dbClient == nil {
+		s.dbClient = pg.NewDB(s.Pool(ctx))
+	}
+
+	return s.dbClient
+}
+
+// TxManager возвращает менеджер транзакций.
+func (s *serviceProvider) TxManager(ctx context.Context) db.TxManager {
+	if s.txManager == nil {
+		s.txManager = transaction.NewTransactionManager(s.DBClient(ctx))
+	}
+
+	return s.txManager
+}
+
+// Hub возвращает локальных подписчиков событий чатов.
+func (s *serviceProvider) Hub() *events.Hub {
+	if s.hub == nil {
+		s.hub = events.NewHub()
+	}
+
+	return s.hub
+}
+
+func (s *serviceProvider) ChatRepository(ctx context.Context) repository.ChatRepository {
+	if s.chatRepository == nil {
+		s.chatRepository = chatRepository.NewRepository(s.DBClient(ctx))
+	}
+
+	return s.chatRepository
+}
+
+func (s *serviceProvider) MemberRepository(ctx context.Context) repository.MemberRepository {
+	if s.memberRepository == nil {
+		s.memberRepository = memberRepository.NewRepository(s.DBClient(ctx))
+	}
+
+	return s.memberRepository
+}
+
+func (s *serviceProvider) MessageRepository(ctx context.Context) repository.MessageRepository {
+	if s.messageRepository == nil {
+		s.messageRepository = messageRepository.NewRepository(s.DBClient(ctx))
+	}
+
+	return s.messageRepository
+}
+
+func (s *serviceProvider) ReactionRepository(ctx context.Context) repository.ReactionRepository {
+	if s.reactionRepository == nil {
+		s.reactionRepository = reactionRepository.NewRepository(s.DBClient(ctx))
+	}
+
+	return s.reactionRepository
+}
+
+func (s *serviceProvider) ConnectionRepository(ctx context.Context) repository.ConnectionRepository {
+	if s.connectionRepository == nil {
+		s.connectionRepository = connectionRepository.NewRepository(s.DBClient(ctx))
+	}
+
+	return s.connectionRepository
+}
+
+func (s *serviceProvider) EventRepository(ctx context.Context) repository.EventRepository {
+	if s.eventRepository == nil {
+		s.eventRepository = eventRepository.NewRepository(s.DBClient(ctx))
+	}
+
+	return s.eventRepository
+}
+
+func (s *serviceProvider) PresenceService(ctx context.Context) service.PresenceService {
+	if s.presenceService == nil {
+		s.presenceService = presenceService.NewService(
+			s.ConnectionRepository(ctx),
+			s.MemberRepository(ctx),
+			s.EventRepository(ctx),
+			s.ChatConfig(),
+			s.log,
+		)
+	}
+
+	return s.presenceService
+}
+
+func (s *serviceProvider) ChatService(ctx context.Context) service.ChatService {
+	if s.chatService == nil {
+		s.chatService = chatService.NewService(
+			s.TxManager(ctx),
+			s.ChatRepository(ctx),
+			s.MemberRepository(ctx),
+			s.EventRepository(ctx),
+			s.PresenceService(ctx),
+			s.Hub(),
+			s.ChatConfig(),
+			s.log,
+		)
+	}
+
+	return s.chatService
+}
+
+func (s *serviceProvider) MessageService(ctx context.Context) service.MessageService {
+	if s.messageService == nil {
+		s.messageService = messageService.NewService(
+			s.TxManager(ctx),
+			s.ChatRepository(ctx),
+			s.MemberRepository(ctx),
+			s.MessageRepository(ctx),
+			s.ReactionRepository(ctx),
+			s.EventRepository(ctx),
+			s.log,
+		)
+	}
+
+	return s.messageService
+}
+
+// ChatImpl возвращает реализацию gRPC API чатов.
+func (s *serviceProvider) ChatImpl(ctx context.Context) *chatAPI.Implementation {
+	if s.chatImpl == nil {
+		s.chatImpl = chatAPI.NewImplementation(
+			s.ChatService(ctx),
+			s.MessageService(ctx),
+			s.PresenceService(ctx),
+			s.log,
+		)
+	}
+
+	return s.chatImpl
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// Handler - функция, выполняемая в транзакции.
+type Handler func(ctx context.Context) error
+
+// TxManager - менеджер транзакций.
+//
+// Выполняет Handler в транзакции с заданным уровнем изоляции. Транзакция передается в Handler
+// через контекст, поэтому все запросы клиента БД с этим контекстом выполняются внутри нее.
+// Если в контексте уже есть транзакция, Handler выполняется в ней, новая транзакция не открывается.
+type TxManager interface {
+	// ReadCommitted выполняет f в транзакции с уровнем изоляции Read Committed.
+	ReadCommitted(ctx context.Context, f Handler) error
+	// Serializable выполняет f в транзакции с уровнем изоляции Serializable.
+	Serializable(ctx context.Context, f Handler) error
+}
+
+// Querier - общий интерфейс пула соединений и транзакции для выполнения запросов к БД.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Transactor - открывает транзакции.
+type Transactor interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// Client - клиент БД: выполняет запросы и открывает транзакции.
+//
+// Запросы выполняются в транзакции из контекста, если она там есть, иначе - на пуле соединений.
+// Репозитории зависят только от этого интерфейса, поэтому в тестах БД можно заменить.
+type Client interface {
+	Querier
+	Transactor
+}
@@ -0,0 +1,60 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+)
+
+type key string
+
+// TxKey - ключ контекста, под которым хранится текущая транзакция.
+const TxKey key = "tx"
+
+type pg struct {
+	pool *pgxpool.Pool
+}
+
+// NewDB создает клиент БД на основе пула соединений Postgres.
+func NewDB(pool *pgxpool.Pool) db.Client {
+	return &pg{
+		pool: pool,
+	}
+}
+
+// Exec выполняет запрос в транзакции из ctx, если она есть, иначе - на пуле соединений.
+func (p *pg) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return p.querier(ctx).Exec(ctx, sql, args...)
+}
+
+// Query выполняет запрос в транзакции из ctx, если она есть, иначе - на пуле соединений.
+func (p *pg) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return p.querier(ctx).Query(ctx, sql, args...)
+}
+
+// QueryRow выполняет запрос в транзакции из ctx, если она есть, иначе - на пуле соединений.
+func (p *pg) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return p.querier(ctx).QueryRow(ctx, sql, args...)
+}
+
+// BeginTx открывает транзакцию на пуле соединений.
+func (p *pg) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return p.pool.BeginTx(ctx, txOptions)
+}
+
+func (p *pg) querier(ctx context.Context) db.Querier {
+	if tx, ok := ctx.Value(TxKey).(pgx.Tx); ok {
+		return tx
+	}
+
+	return p.pool
+}
+
+// MakeContextTx возвращает контекст, содержащий транзакцию tx.
+func MakeContextTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, TxKey, tx)
+}
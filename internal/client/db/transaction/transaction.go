@@ -0,0 +1,68 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/client/db/pg"
+)
+
+type manager struct {
+	db db.Transactor
+}
+
+// NewTransactionManager создает менеджер транзакций.
+func NewTransactionManager(db db.Transactor) db.TxManager {
+	return &manager{
+		db: db,
+	}
+}
+
+// ReadCommitted выполняет f в транзакции с уровнем изоляции Read Committed.
+func (m *manager) ReadCommitted(ctx context.Context, f db.Handler) error {
+	return m.transaction(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, f)
+}
+
+// Serializable выполняет f в транзакции с уровнем изоляции Serializable.
+func (m *manager) Serializable(ctx context.Context, f db.Handler) error {
+	return m.transaction(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, f)
+}
+
+// transaction выполняет fn в транзакции.
+//
+// Вложенный вызов выполняет fn в уже открытой транзакции: коммит или откат выполнит внешний вызов.
+// Транзакция откатывается, если fn вернула ошибку или запаниковала, иначе коммитится.
+// Ошибка fn возвращается без изменений, чтобы сохранить gRPC-код, с которым ее создал сервис.
+func (m *manager) transaction(ctx context.Context, opts pgx.TxOptions, fn db.Handler) (err error) {
+	if _, ok := ctx.Value(pg.TxKey).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("unable to start transaction: %w", err)
+	}
+
+	ctx = pg.MakeContextTx(ctx, tx)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic recovered: %v", r)
+		}
+
+		if err != nil {
+			// Откат после ошибки не меняет результат: транзакция в любом случае не применится
+			_ = tx.Rollback(ctx)
+			return
+		}
+
+		if err = tx.Commit(ctx); err != nil {
+			err = fmt.Errorf("unable to commit transaction: %w", err)
+		}
+	}()
+
+	return fn(ctx)
+}
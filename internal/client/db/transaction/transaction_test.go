@@ -0,0 +1,148 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/anton0701/chat-server/internal/client/db/pg"
+)
+
+// fakeTx - транзакция, запоминающая, была ли она закоммичена или откачена.
+// Остальные методы pgx.Tx в тестах не вызываются.
+type fakeTx struct {
+	pgx.Tx
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Commit(_ context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(_ context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+// fakeTransactor открывает fakeTx и запоминает открытые транзакции.
+type fakeTransactor struct {
+	txs  []*fakeTx
+	opts []pgx.TxOptions
+}
+
+func (t *fakeTransactor) BeginTx(_ context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	tx := &fakeTx{}
+	t.txs = append(t.txs, tx)
+	t.opts = append(t.opts, txOptions)
+	return tx, nil
+}
+
+func TestTransactionCommitsOnSuccess(t *testing.T) {
+	transactor := &fakeTransactor{}
+	manager := NewTransactionManager(transactor)
+
+	var inTx bool
+	err := manager.ReadCommitted(context.Background(), func(ctx context.Context) error {
+		_, inTx = ctx.Value(pg.TxKey).(pgx.Tx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inTx {
+		t.Fatal("handler context does not contain transaction")
+	}
+	if len(transactor.txs) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(transactor.txs))
+	}
+	if transactor.opts[0].IsoLevel != pgx.ReadCommitted {
+		t.Fatalf("expected isolation level %q, got %q", pgx.ReadCommitted, transactor.opts[0].IsoLevel)
+	}
+	if tx := transactor.txs[0]; !tx.committed || tx.rolledBack {
+		t.Fatalf("expected commit without rollback, got committed=%v rolledBack=%v", tx.committed, tx.rolledBack)
+	}
+}
+
+func TestTransactionRollsBackOnError(t *testing.T) {
+	transactor := &fakeTransactor{}
+	manager := NewTransactionManager(transactor)
+	handlerErr := errors.New("handler failed")
+
+	err := manager.Serializable(context.Background(), func(_ context.Context) error {
+		return handlerErr
+	})
+	if !errors.Is(err, handlerErr) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+	if transactor.opts[0].IsoLevel != pgx.Serializable {
+		t.Fatalf("expected isolation level %q, got %q", pgx.Serializable, transactor.opts[0].IsoLevel)
+	}
+	if tx := transactor.txs[0]; tx.committed || !tx.rolledBack {
+		t.Fatalf("expected rollback without commit, got committed=%v rolledBack=%v", tx.committed, tx.rolledBack)
+	}
+}
+
+func TestTransactionRollsBackOnPanic(t *testing.T) {
+	transactor := &fakeTransactor{}
+	manager := NewTransactionManager(transactor)
+
+	err := manager.ReadCommitted(context.Background(), func(_ context.Context) error {
+		panic("handler panicked")
+	})
+	if err == nil {
+		t.Fatal("expected error after panic")
+	}
+	if tx := transactor.txs[0]; tx.committed || !tx.rolledBack {
+		t.Fatalf("expected rollback without commit, got committed=%v rolledBack=%v", tx.committed, tx.rolledBack)
+	}
+}
+
+func TestNestedTransactionJoinsOuter(t *testing.T) {
+	transactor := &fakeTransactor{}
+	manager := NewTransactionManager(transactor)
+
+	err := manager.ReadCommitted(context.Background(), func(ctx context.Context) error {
+		outer := ctx.Value(pg.TxKey).(pgx.Tx)
+
+		return manager.Serializable(ctx, func(ctx context.Context) error {
+			if inner := ctx.Value(pg.TxKey).(pgx.Tx); inner != outer {
+				t.Error("nested handler runs in another transaction")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transactor.txs) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(transactor.txs))
+	}
+	if tx := transactor.txs[0]; !tx.committed || tx.rolledBack {
+		t.Fatalf("expected commit without rollback, got committed=%v rolledBack=%v", tx.committed, tx.rolledBack)
+	}
+}
+
+func TestNestedErrorRollsBackOuter(t *testing.T) {
+	transactor := &fakeTransactor{}
+	manager := NewTransactionManager(transactor)
+	handlerErr := errors.New("nested handler failed")
+
+	err := manager.ReadCommitted(context.Background(), func(ctx context.Context) error {
+		return manager.ReadCommitted(ctx, func(_ context.Context) error {
+			return handlerErr
+		})
+	})
+	if !errors.Is(err, handlerErr) {
+		t.Fatalf("expected nested handler error, got %v", err)
+	}
+	if len(transactor.txs) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(transactor.txs))
+	}
+	if tx := transactor.txs[0]; tx.committed || !tx.rolledBack {
+		t.Fatalf("expected rollback without commit, got committed=%v rolledBack=%v", tx.committed, tx.rolledBack)
+	}
+}
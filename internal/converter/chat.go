@@ -0,0 +1,171 @@
+package converter
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// ToChatInfoFromDesc преобразует запрос на создание чата в model.ChatInfo.
+func ToChatInfoFromDesc(req *desc.CreateChatRequest) *model.ChatInfo {
+	return &model.ChatInfo{
+		Name:           req.ChatName,
+		Description:    req.ChatDescription.GetValue(),
+		OwnerID:        req.Owner_ID,
+		UserIDs:        req.User_IDs,
+		IdempotencyKey: req.IdempotencyKey,
+	}
+}
+
+// ToChatUpdateFromDesc преобразует запрос на изменение чата в model.ChatUpdate.
+//
+// Изменяются только поля из update_mask. Описание чата очищается, если поле chat_description
+// указано в update_mask, но не заполнено.
+func ToChatUpdateFromDesc(req *desc.UpdateChatRequest) *model.ChatUpdate {
+	update := &model.ChatUpdate{}
+	for _, path := range req.UpdateMask.GetPaths() {
+		switch path {
+		case desc.UpdateChatFieldName:
+			update.UpdateName = true
+			update.Name = req.ChatName
+		case desc.UpdateChatFieldDescription:
+			update.UpdateDescription = true
+			if req.ChatDescription != nil {
+				update.Description = &req.ChatDescription.Value
+			}
+		}
+	}
+
+	return update
+}
+
+// ToGetChatResponseFromService преобразует чат и его участников в ответ GetChat.
+func ToGetChatResponseFromService(chat *model.Chat, members []*model.ChatMember) *desc.GetChatResponse {
+	userIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+
+	resp := &desc.GetChatResponse{
+		ID:       chat.ID,
+		ChatName: chat.Name,
+		User_IDs: userIDs,
+		Members:  ToChatMembersFromService(members),
+		Kind:     ToChatKindFromService(chat.Kind),
+	}
+	if chat.Description != nil {
+		resp.ChatDescription = wrapperspb.String(*chat.Description)
+	}
+
+	return resp
+}
+
+// ToChatSummariesFromService преобразует страницу чатов пользователя в []*desc.ChatSummary.
+func ToChatSummariesFromService(chats []*model.ChatSummary) []*desc.ChatSummary {
+	summaries := make([]*desc.ChatSummary, 0, len(chats))
+	for _, chat := range chats {
+		summary := &desc.ChatSummary{
+			ID:             chat.ID,
+			ChatName:       chat.Name,
+			MemberCount:    chat.MemberCount,
+			LastActivityAt: timestamppb.New(chat.LastActivityAt),
+			UnreadCount:    chat.UnreadCount,
+			Kind:           ToChatKindFromService(chat.Kind),
+		}
+		if chat.Description != nil {
+			summary.ChatDescription = wrapperspb.String(*chat.Description)
+		}
+		if chat.LastMessage != nil {
+			summary.LastMessage = ToMessageFromService(chat.LastMessage)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+// ToChatMembersFromService преобразует участников чата в []*desc.ChatMember.
+func ToChatMembersFromService(members []*model.ChatMember) []*desc.ChatMember {
+	result := make([]*desc.ChatMember, 0, len(members))
+	for _, member := range members {
+		result = append(result, ToChatMemberFromService(member))
+	}
+
+	return result
+}
+
+// ToChatMemberFromService преобразует участника чата в desc.ChatMember.
+func ToChatMemberFromService(member *model.ChatMember) *desc.ChatMember {
+	return &desc.ChatMember{
+		User_ID: member.UserID,
+		Role:    ToRoleFromService(member.Role),
+	}
+}
+
+// ToRoleFromService преобразует роль участника чата в desc.ChatRole.
+func ToRoleFromService(role string) desc.ChatRole {
+	switch role {
+	case model.RoleOwner:
+		return desc.ChatRole_CHAT_ROLE_OWNER
+	case model.RoleAdmin:
+		return desc.ChatRole_CHAT_ROLE_ADMIN
+	case model.RoleMember:
+		return desc.ChatRole_CHAT_ROLE_MEMBER
+	}
+
+	return desc.ChatRole_CHAT_ROLE_UNSPECIFIED
+}
+
+// ToRoleFromDesc преобразует desc.ChatRole в роль участника чата.
+func ToRoleFromDesc(role desc.ChatRole) string {
+	switch role {
+	case desc.ChatRole_CHAT_ROLE_OWNER:
+		return model.RoleOwner
+	case desc.ChatRole_CHAT_ROLE_ADMIN:
+		return model.RoleAdmin
+	}
+
+	return model.RoleMember
+}
+
+// ToChatKindFromService преобразует вид чата в desc.ChatKind.
+func ToChatKindFromService(kind string) desc.ChatKind {
+	switch kind {
+	case model.ChatKindGroup:
+		return desc.ChatKind_CHAT_KIND_GROUP
+	case model.ChatKindDirect:
+		return desc.ChatKind_CHAT_KIND_DIRECT
+	}
+
+	return desc.ChatKind_CHAT_KIND_UNSPECIFIED
+}
+
+// ToReadStateFromService преобразует состояние прочтения чата в desc.ChatReadState.
+func ToReadStateFromService(state *model.ReadState) *desc.ChatReadState {
+	return &desc.ChatReadState{
+		Chat_ID:            state.ChatID,
+		User_ID:            state.UserID,
+		LastReadMessage_ID: state.LastReadMessageID,
+		UnreadCount:        state.UnreadCount,
+	}
+}
+
+// ToUserPresencesFromService преобразует присутствие пользователей в []*desc.UserPresence.
+func ToUserPresencesFromService(presences []*model.UserPresence) []*desc.UserPresence {
+	result := make([]*desc.UserPresence, 0, len(presences))
+	for _, presence := range presences {
+		result = append(result, ToUserPresenceFromService(presence))
+	}
+
+	return result
+}
+
+// ToUserPresenceFromService преобразует присутствие пользователя в desc.UserPresence.
+func ToUserPresenceFromService(presence *model.UserPresence) *desc.UserPresence {
+	return &desc.UserPresence{
+		User_ID: presence.UserID,
+		Online:  presence.Online,
+	}
+}
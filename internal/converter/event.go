@@ -0,0 +1,91 @@
+package converter
+
+import (
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// ToChatEventFromService преобразует событие чата в desc.ChatEvent.
+func ToChatEventFromService(event *model.ChatEvent) *desc.ChatEvent {
+	switch {
+	case event.Message != nil:
+		return &desc.ChatEvent{
+			Event: &desc.ChatEvent_Message{
+				Message: ToMessageFromService(event.Message),
+			},
+		}
+	case event.MessageEdited != nil:
+		return &desc.ChatEvent{
+			Event: &desc.ChatEvent_MessageEdited{
+				MessageEdited: ToMessageFromService(event.MessageEdited),
+			},
+		}
+	case event.MessageDeleted != nil:
+		return &desc.ChatEvent{
+			Event: &desc.ChatEvent_MessageDeleted{
+				MessageDeleted: ToMessageFromService(event.MessageDeleted),
+			},
+		}
+	case event.ReactionsUpdated != nil:
+		return &desc.ChatEvent{
+			Event: &desc.ChatEvent_ReactionsUpdated{
+				ReactionsUpdated: ToMessageReactionsFromService(event.ReactionsUpdated),
+			},
+		}
+	case event.Typing != nil:
+		return &desc.ChatEvent{
+			Event: &desc.ChatEvent_Typing{
+				Typing: ToTypingEventFromService(event.Typing),
+			},
+		}
+	case event.Presence != nil:
+		return &desc.ChatEvent{
+			Event: &desc.ChatEvent_Presence{
+				Presence: ToUserPresenceFromService(event.Presence),
+			},
+		}
+	case event.MembersRemoved != nil:
+		return &desc.ChatEvent{
+			Event: &desc.ChatEvent_MembersRemoved{
+				MembersRemoved: ToChatMembersRemovedFromService(event.MembersRemoved),
+			},
+		}
+	case event.ChatDeleted != nil:
+		return &desc.ChatEvent{
+			Event: &desc.ChatEvent_ChatDeleted{
+				ChatDeleted: ToChatDeletedFromService(event.ChatDeleted),
+			},
+		}
+	}
+
+	return &desc.ChatEvent{}
+}
+
+// ToTypingEventFromService преобразует изменение индикатора набора текста в desc.TypingEvent.
+func ToTypingEventFromService(event *model.TypingEvent) *desc.TypingEvent {
+	state := desc.TypingState_TYPING_STATE_STOPPED
+	if event.Typing {
+		state = desc.TypingState_TYPING_STATE_STARTED
+	}
+
+	return &desc.TypingEvent{
+		Chat_ID: event.ChatID,
+		User_ID: event.UserID,
+		State:   state,
+	}
+}
+
+// ToChatMembersRemovedFromService преобразует удаление участников чата в desc.ChatMembersRemoved.
+func ToChatMembersRemovedFromService(event *model.ChatMembersRemoved) *desc.ChatMembersRemoved {
+	return &desc.ChatMembersRemoved{
+		Chat_ID:  event.ChatID,
+		User_IDs: event.UserIDs,
+	}
+}
+
+// ToChatDeletedFromService преобразует удаление чата в desc.ChatDeleted.
+func ToChatDeletedFromService(event *model.ChatDeleted) *desc.ChatDeleted {
+	return &desc.ChatDeleted{
+		Chat_ID: event.ChatID,
+	}
+}
@@ -0,0 +1,115 @@
+package converter
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// ToMessageInfoFromDesc преобразует запрос на отправку сообщения в model.MessageInfo.
+func ToMessageInfoFromDesc(req *desc.SendMessageRequest) *model.MessageInfo {
+	info := &model.MessageInfo{
+		ChatID:           req.Chat_ID,
+		UserID:           req.User_IDFrom,
+		Text:             req.Text,
+		ReplyToMessageID: req.ReplyToMessage_ID,
+		IdempotencyKey:   req.IdempotencyKey,
+	}
+	// Время отправки по часам клиента сохраняется, только если клиент его передал
+	if req.Timestamp != nil {
+		clientCreatedAt := req.Timestamp.AsTime()
+		info.ClientCreatedAt = &clientCreatedAt
+	}
+
+	return info
+}
+
+// ToMessagesFromService преобразует сообщения в []*desc.Message.
+func ToMessagesFromService(messages []*model.Message) []*desc.Message {
+	result := make([]*desc.Message, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, ToMessageFromService(message))
+	}
+
+	return result
+}
+
+// ToMessageFromService преобразует сообщение в desc.Message.
+func ToMessageFromService(message *model.Message) *desc.Message {
+	result := &desc.Message{
+		ID:                message.ID,
+		Chat_ID:           message.ChatID,
+		User_IDFrom:       message.UserID,
+		Text:              message.Text,
+		CreatedAt:         timestamppb.New(message.CreatedAt),
+		ReplyToMessage_ID: message.ReplyToMessageID,
+		Reactions:         ToReactionsFromService(message.Reactions),
+		ReadByUser_IDs:    message.ReadByUserIDs,
+		ClientCreatedAt:   toTimestamp(message.ClientCreatedAt),
+		Seq:               message.Seq,
+	}
+	if message.EditedAt != nil {
+		result.Edited = true
+		result.EditedAt = timestamppb.New(*message.EditedAt)
+	}
+	if message.DeletedAt != nil {
+		result.Deleted = true
+		result.DeletedAt = timestamppb.New(*message.DeletedAt)
+	}
+
+	return result
+}
+
+// ToReactionsFromService преобразует реакции на сообщение в []*desc.Reaction.
+//
+// Для сообщения без реакций возвращает nil.
+func ToReactionsFromService(reactions []*model.Reaction) []*desc.Reaction {
+	if len(reactions) == 0 {
+		return nil
+	}
+
+	result := make([]*desc.Reaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		result = append(result, &desc.Reaction{
+			Emoji: reaction.Emoji,
+			Count: reaction.Count,
+		})
+	}
+
+	return result
+}
+
+// ToMessageReactionsFromService преобразует набор реакций на сообщение в desc.MessageReactions.
+func ToMessageReactionsFromService(reactions *model.MessageReactions) *desc.MessageReactions {
+	return &desc.MessageReactions{
+		Message_ID: reactions.MessageID,
+		Chat_ID:    reactions.ChatID,
+		Reactions:  ToReactionsFromService(reactions.Reactions),
+	}
+}
+
+// ToSearchHitsFromService преобразует найденные сообщения в []*desc.SearchHit.
+func ToSearchHitsFromService(hits []*model.SearchHit) []*desc.SearchHit {
+	result := make([]*desc.SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		result = append(result, &desc.SearchHit{
+			Message: ToMessageFromService(hit.Message),
+			Snippet: hit.Snippet,
+			Rank:    hit.Rank,
+		})
+	}
+
+	return result
+}
+
+// toTimestamp преобразует необязательное время в *timestamppb.Timestamp.
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
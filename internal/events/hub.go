@@ -0,0 +1,106 @@
+package events
+
+import (
+	"sync"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+)
+
+// subscriberBufferSize - размер буфера событий одного подписчика.
+//
+// Если подписчик не успевает вычитывать события и буфер заполняется, подписчик отключается.
+const subscriberBufferSize = 64
+
+// Subscriber - подписчик на события одного чата (одно активное потоковое подключение).
+type Subscriber struct {
+	userID int64
+	events chan *desc.ChatEvent
+}
+
+// Events возвращает канал событий подписчика.
+//
+// Канал закрывается, если хаб отключил подписчика из-за переполнения буфера.
+func (s *Subscriber) Events() <-chan *desc.ChatEvent {
+	return s.events
+}
+
+// Hub - внутрипроцессный брокер событий чатов.
+//
+// Хранит подписчиков каждого чата и рассылает им события, опубликованные через Publish.
+// Безопасен для конкурентного использования.
+type Hub struct {
+	mu    sync.Mutex
+	chats map[int64]map[*Subscriber]struct{}
+}
+
+// NewHub создает пустой брокер событий чатов.
+func NewHub() *Hub {
+	return &Hub{
+		chats: make(map[int64]map[*Subscriber]struct{}),
+	}
+}
+
+// Subscribe регистрирует нового подписчика на события чата chatID.
+//
+// Подписчик должен быть удален через Unsubscribe после завершения подключения.
+// Канал событий подписчика закрывается, если хаб отключил его из-за переполнения буфера.
+func (h *Hub) Subscribe(chatID, userID int64) *Subscriber {
+	sub := &Subscriber{
+		userID: userID,
+		events: make(chan *desc.ChatEvent, subscriberBufferSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.chats[chatID]
+	if !ok {
+		subs = make(map[*Subscriber]struct{})
+		h.chats[chatID] = subs
+	}
+	subs[sub] = struct{}{}
+
+	return sub
+}
+
+// Unsubscribe удаляет подписчика чата chatID и закрывает его канал событий.
+// Повторный вызов для уже удаленного подписчика ничего не делает.
+func (h *Hub) Unsubscribe(chatID int64, sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(chatID, sub)
+}
+
+// Publish рассылает событие всем подписчикам чата chatID.
+//
+// Отправка не блокируется: подписчик, буфер которого заполнен, отключается.
+func (h *Hub) Publish(chatID int64, event *desc.ChatEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.chats[chatID] {
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(chatID, sub)
+		}
+	}
+}
+
+// remove удаляет подписчика и закрывает его канал. Вызывается под мьютексом.
+func (h *Hub) remove(chatID int64, sub *Subscriber) {
+	subs, ok := h.chats[chatID]
+	if !ok {
+		return
+	}
+	if _, ok = subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.events)
+	if len(subs) == 0 {
+		delete(h.chats, chatID)
+	}
+}
@@ -0,0 +1,215 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	desc "github.com/anton0701/chat-server/grpc/pkg/chat_v1"
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/repository"
+)
+
+const (
+	// chatEventsChannel - канал Postgres LISTEN/NOTIFY, через который экземпляры сервера обмениваются событиями чатов
+	chatEventsChannel = "chat_events"
+
+	// maxNotifyPayloadSize - ограничение Postgres на размер payload в NOTIFY (8000 байт) с небольшим запасом
+	maxNotifyPayloadSize = 7900
+
+	// listenRetryDelay - пауза перед повторным подключением слушателя после ошибки
+	listenRetryDelay = time.Second
+)
+
+// chatNotification - payload уведомления в канале chatEventsChannel.
+//
+// Содержит событие целиком (Event). Если событие не помещается в NOTIFY, текст сообщения
+// из события вырезается и передается только MessageID: слушатель загружает текст из БД.
+type chatNotification struct {
+	ChatID    int64           `json:"chat_id"`
+	Event     json.RawMessage `json:"event"`
+	MessageID int64           `json:"message_id,omitempty"`
+}
+
+// Notify отправляет событие чата в канал chatEventsChannel.
+//
+// Если q - транзакция, Postgres доставляет уведомление слушателям только после ее коммита,
+// поэтому подписчики не увидят событие, если транзакция будет отменена.
+func Notify(ctx context.Context, q db.Querier, chatID int64, event *desc.ChatEvent) error {
+	payload, err := encodeChatNotification(chatID, event, 0)
+	if err != nil {
+		return err
+	}
+
+	// Слишком большое сообщение передаем без текста, слушатель загрузит его из БД
+	if len(payload) > maxNotifyPayloadSize {
+		message := eventMessage(event)
+		if message == nil {
+			return fmt.Errorf("chat event is too large for notification: %d bytes", len(payload))
+		}
+
+		trimmedEvent := proto.Clone(event).(*desc.ChatEvent)
+		eventMessage(trimmedEvent).Text = ""
+		payload, err = encodeChatNotification(chatID, trimmedEvent, message.ID)
+		if err != nil {
+			return err
+		}
+
+		// Кроме текста событие содержит реакции и список прочитавших, которые тоже могут не поместиться
+		if len(payload) > maxNotifyPayloadSize {
+			return fmt.Errorf("chat event is too large for notification: %d bytes", len(payload))
+		}
+	}
+
+	_, err = q.Exec(ctx, "SELECT pg_notify($1, $2)", chatEventsChannel, string(payload))
+	if err != nil {
+		return fmt.Errorf("unable to send notification: %w", err)
+	}
+
+	return nil
+}
+
+// encodeChatNotification кодирует payload уведомления.
+func encodeChatNotification(chatID int64, event *desc.ChatEvent, messageID int64) ([]byte, error) {
+	encodedEvent, err := protojson.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode chat event: %w", err)
+	}
+
+	payload, err := json.Marshal(chatNotification{
+		ChatID:    chatID,
+		Event:     encodedEvent,
+		MessageID: messageID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode notification: %w", err)
+	}
+
+	return payload, nil
+}
+
+// eventMessage возвращает сообщение, которое переносит событие, либо nil, если событие не содержит сообщения.
+func eventMessage(event *desc.ChatEvent) *desc.Message {
+	switch e := event.Event.(type) {
+	case *desc.ChatEvent_Message:
+		return e.Message
+	case *desc.ChatEvent_MessageEdited:
+		return e.MessageEdited
+	case *desc.ChatEvent_MessageDeleted:
+		return e.MessageDeleted
+	}
+
+	return nil
+}
+
+// Listener слушает канал chatEventsChannel и передает полученные события
+// локальным подписчикам через Hub.
+//
+// Благодаря этому сообщение, отправленное через один экземпляр сервера,
+// доставляется подписчикам, подключенным к любому другому экземпляру.
+type Listener struct {
+	pool              *pgxpool.Pool
+	hub               *Hub
+	messageRepository repository.MessageRepository
+	log               *zap.Logger
+}
+
+// NewListener создает слушателя событий чатов.
+//
+// Слушатель занимает отдельное соединение пула, поэтому работает напрямую с *pgxpool.Pool.
+// Тексты сообщений, не поместившиеся в уведомление, загружаются через messageRepository.
+func NewListener(pool *pgxpool.Pool, hub *Hub, messageRepository repository.MessageRepository, logger *zap.Logger) *Listener {
+	return &Listener{
+		pool:              pool,
+		hub:               hub,
+		messageRepository: messageRepository,
+		log:               logger,
+	}
+}
+
+// Run слушает уведомления до отмены ctx, переподключаясь после ошибок.
+func (l *Listener) Run(ctx context.Context) {
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		l.log.Error("Chat events listener stopped, reconnecting", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+// listen занимает отдельное соединение, подписывается на канал и обрабатывает уведомления.
+func (l *Listener) listen(ctx context.Context) error {
+	poolConn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to acquire connection: %w", err)
+	}
+	// Соединение с активным LISTEN не должно вернуться в пул
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+chatEventsChannel)
+	if err != nil {
+		return fmt.Errorf("unable to listen channel: %w", err)
+	}
+	l.log.Info("Listening chat events", zap.String("channel", chatEventsChannel))
+
+	for {
+		notification, waitErr := conn.WaitForNotification(ctx)
+		if waitErr != nil {
+			return fmt.Errorf("unable to wait for notification: %w", waitErr)
+		}
+
+		l.handle(ctx, notification.Payload)
+	}
+}
+
+// handle декодирует уведомление и публикует событие в Hub.
+func (l *Listener) handle(ctx context.Context, payload string) {
+	var notification chatNotification
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		l.log.Error("Unable to decode chat notification", zap.Error(err))
+		return
+	}
+
+	event := &desc.ChatEvent{}
+	if err := protojson.Unmarshal(notification.Event, event); err != nil {
+		l.log.Error("Unable to decode chat event", zap.Error(err))
+		return
+	}
+
+	// Текст сообщения не поместился в уведомление - загружаем его из БД
+	if notification.MessageID != 0 {
+		message := eventMessage(event)
+		if message == nil {
+			l.log.Error("Chat notification refers to message, but event has no message", zap.Int64("message_id", notification.MessageID))
+			return
+		}
+
+		stored, found, err := l.messageRepository.Get(ctx, notification.MessageID, false)
+		if err != nil {
+			l.log.Error("Unable to load message for chat notification", zap.Int64("message_id", notification.MessageID), zap.Error(err))
+			return
+		}
+		// Чат мог быть удален до получения уведомления - его событие уже некому доставлять
+		if !found {
+			l.log.Info("Message for chat notification not found", zap.Int64("message_id", notification.MessageID))
+			return
+		}
+		message.Text = stored.Text
+	}
+
+	l.hub.Publish(notification.ChatID, event)
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"time"
+)
+
+// Роли участников чата.
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+// Виды чатов.
+const (
+	ChatKindGroup  = "group"
+	ChatKindDirect = "direct"
+)
+
+// IsChatManager сообщает, может ли участник с ролью role управлять чатом:
+// изменять его, управлять участниками и удалять чужие сообщения.
+func IsChatManager(role string) bool {
+	return role == RoleOwner || role == RoleAdmin
+}
+
+// Chat - чат.
+type Chat struct {
+	ID          int64
+	Name        string
+	Description *string
+	Kind        string
+}
+
+// ChatInfo - данные создаваемого группового чата.
+//
+// Если OwnerID не указан, владельцем становится первый пользователь из UserIDs.
+type ChatInfo struct {
+	Name           string
+	Description    string
+	OwnerID        int64
+	UserIDs        []int64
+	IdempotencyKey string
+}
+
+// ChatUpdate - изменение чата. Изменяются только поля, отмеченные флагами Update*.
+type ChatUpdate struct {
+	UpdateName        bool
+	Name              string
+	UpdateDescription bool
+	Description       *string
+}
+
+// ChatMember - участник чата.
+type ChatMember struct {
+	UserID int64
+	Role   string
+}
+
+// ChatSummary - чат в списке чатов пользователя.
+type ChatSummary struct {
+	Chat
+	MemberCount    int64
+	LastMessage    *Message
+	LastActivityAt time.Time
+	UnreadCount    int64
+}
+
+// ReadState - состояние прочтения чата участником.
+//
+// LastReadMessageID равен 0, если участник еще не читал чат.
+type ReadState struct {
+	ChatID            int64
+	UserID            int64
+	LastReadMessageID int64
+	UnreadCount       int64
+}
+
+// ReadPointer - последнее прочитанное участником сообщение.
+type ReadPointer struct {
+	UserID    int64
+	At        time.Time
+	MessageID int64
+}
+
+// Covers сообщает, прочитано ли сообщение message участником с указателем p.
+func (p ReadPointer) Covers(message *Message) bool {
+	if p.At.Equal(message.CreatedAt) {
+		return p.MessageID >= message.ID
+	}
+
+	return p.At.After(message.CreatedAt)
+}
+
+// PageCursor - позиция записи в выдаче, упорядоченной по паре (время, id).
+type PageCursor struct {
+	At time.Time
+	ID int64
+}
+
+// RankCursor - позиция записи в выдаче, упорядоченной по паре (ранг, id).
+type RankCursor struct {
+	Rank float32
+	ID   int64
+}
@@ -0,0 +1,39 @@
+package model
+
+// ChatEvent - событие чата, которое получают подключенные участники.
+//
+// Заполнено ровно одно поле.
+type ChatEvent struct {
+	Message          *Message
+	MessageEdited    *Message
+	MessageDeleted   *Message
+	ReactionsUpdated *MessageReactions
+	Typing           *TypingEvent
+	Presence         *UserPresence
+	MembersRemoved   *ChatMembersRemoved
+	ChatDeleted      *ChatDeleted
+}
+
+// TypingEvent - изменение индикатора набора текста участником чата.
+type TypingEvent struct {
+	ChatID int64
+	UserID int64
+	Typing bool
+}
+
+// ChatMembersRemoved - участники, удаленные из чата.
+type ChatMembersRemoved struct {
+	ChatID  int64
+	UserIDs []int64
+}
+
+// ChatDeleted - удаление чата.
+type ChatDeleted struct {
+	ChatID int64
+}
+
+// UserPresence - присутствие пользователя в сети.
+type UserPresence struct {
+	UserID int64
+	Online bool
+}
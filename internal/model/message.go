@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+)
+
+// Message - сообщение чата.
+//
+// ReplyToMessageID равен 0, если сообщение не является ответом.
+type Message struct {
+	ID               int64
+	ChatID           int64
+	UserID           int64
+	Text             string
+	CreatedAt        time.Time
+	EditedAt         *time.Time
+	DeletedAt        *time.Time
+	ReplyToMessageID int64
+	ClientCreatedAt  *time.Time
+	Seq              int64
+	Reactions        []*Reaction
+	ReadByUserIDs    []int64
+}
+
+// MessageInfo - данные отправляемого сообщения.
+type MessageInfo struct {
+	ChatID           int64
+	UserID           int64
+	Text             string
+	ClientCreatedAt  *time.Time
+	ReplyToMessageID int64
+	IdempotencyKey   string
+}
+
+// ListMessagesParams - параметры выборки страницы истории сообщений чата.
+//
+// Указывается не больше одного курсора: Before, After или AfterSeq.
+// Если курсор не указан, выбираются последние сообщения чата.
+type ListMessagesParams struct {
+	ChatID   int64
+	UserID   int64
+	Limit    int64
+	Before   *PageCursor
+	After    *PageCursor
+	AfterSeq *int64
+}
+
+// Reaction - реакция на сообщение и количество поставивших ее пользователей.
+type Reaction struct {
+	Emoji string
+	Count int64
+}
+
+// MessageReactions - набор реакций на сообщение.
+type MessageReactions struct {
+	MessageID int64
+	ChatID    int64
+	Reactions []*Reaction
+}
+
+// SearchParams - параметры полнотекстового поиска сообщений.
+//
+// Если ChatID равен 0, поиск выполняется по всем чатам пользователя.
+type SearchParams struct {
+	UserID int64
+	Query  string
+	ChatID int64
+	Limit  int64
+	After  *RankCursor
+}
+
+// SearchHit - найденное сообщение.
+type SearchHit struct {
+	Message *Message
+	Snippet string
+	Rank    float32
+}
@@ -0,0 +1,448 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/repository"
+	"github.com/anton0701/chat-server/internal/repository/member"
+)
+
+const (
+	// uniqueViolationCode - код ошибки Postgres при нарушении уникального индекса
+	uniqueViolationCode = "23505"
+	// directUsersIndex - уникальный индекс неудаленного личного чата пары пользователей
+	directUsersIndex = "chats_direct_users_idx"
+)
+
+type repo struct {
+	db db.Client
+}
+
+// NewRepository создает репозиторий чатов.
+func NewRepository(db db.Client) repository.ChatRepository {
+	return &repo{
+		db: db,
+	}
+}
+
+// Create создает групповой чат.
+//
+// Если владелец уже создал чат с тем же ключом идемпотентности, вставка пропускается.
+func (r *repo) Create(ctx context.Context, info *model.ChatInfo) (int64, bool, error) {
+	var idempotencyKey *string
+	if info.IdempotencyKey != "" {
+		idempotencyKey = &info.IdempotencyKey
+	}
+
+	insertChatBuilder := sq.
+		Insert("chats").
+		PlaceholderFormat(sq.Dollar).
+		Columns("name", "description", "created_by", "idempotency_key").
+		Values(info.Name, info.Description, info.OwnerID, idempotencyKey).
+		Suffix("ON CONFLICT (created_by, idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING RETURNING id")
+
+	query, args, err := insertChatBuilder.ToSql()
+	if err != nil {
+		return 0, false, err
+	}
+
+	var chatID int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&chatID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return chatID, true, nil
+}
+
+// GetIDByIdempotencyKey возвращает ID чата, созданного пользователем с ключом идемпотентности.
+func (r *repo) GetIDByIdempotencyKey(ctx context.Context, createdBy int64, key string) (int64, error) {
+	selectChatBuilder := sq.
+		Select("id").
+		From("chats").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"created_by": createdBy, "idempotency_key": key})
+
+	query, args, err := selectChatBuilder.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var chatID int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&chatID)
+	if err != nil {
+		return 0, err
+	}
+
+	return chatID, nil
+}
+
+// CreateDirect создает личный чат пары пользователей.
+//
+// Если чат для пары уже существует (в том числе создается параллельной транзакцией), вставка пропускается:
+// это гарантирует уникальный индекс по нормализованной паре ID.
+func (r *repo) CreateDirect(ctx context.Context, userLow, userHigh int64) (int64, bool, error) {
+	insertChatBuilder := sq.
+		Insert("chats").
+		PlaceholderFormat(sq.Dollar).
+		Columns("name", "kind", "direct_user_low", "direct_user_high").
+		Values("", model.ChatKindDirect, userLow, userHigh).
+		Suffix("ON CONFLICT (direct_user_low, direct_user_high) WHERE deleted_at IS NULL DO NOTHING RETURNING id")
+
+	query, args, err := insertChatBuilder.ToSql()
+	if err != nil {
+		return 0, false, err
+	}
+
+	var chatID int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&chatID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return chatID, true, nil
+}
+
+// GetDirectID возвращает ID неудаленного личного чата пары пользователей.
+func (r *repo) GetDirectID(ctx context.Context, userLow, userHigh int64) (int64, error) {
+	selectChatBuilder := sq.
+		Select("id").
+		From("chats").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"direct_user_low": userLow, "direct_user_high": userHigh, "deleted_at": nil})
+
+	query, args, err := selectChatBuilder.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var chatID int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&chatID)
+	if err != nil {
+		return 0, err
+	}
+
+	return chatID, nil
+}
+
+// Get возвращает неудаленный чат.
+func (r *repo) Get(ctx context.Context, chatID int64) (*model.Chat, bool, error) {
+	selectChatBuilder := sq.
+		Select("name", "description", "kind").
+		From("chats").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": chatID, "deleted_at": nil})
+
+	query, args, err := selectChatBuilder.ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	chat := &model.Chat{
+		ID: chatID,
+	}
+	err = r.db.QueryRow(ctx, query, args...).Scan(&chat.Name, &chat.Description, &chat.Kind)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return chat, true, nil
+}
+
+// Lock блокирует строку неудаленного чата до конца транзакции.
+func (r *repo) Lock(ctx context.Context, chatID int64, forUpdate bool) (bool, error) {
+	lockMode := "FOR SHARE"
+	if forUpdate {
+		lockMode = "FOR UPDATE"
+	}
+
+	selectChatBuilder := sq.
+		Select("1").
+		From("chats").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": chatID, "deleted_at": nil}).
+		Suffix(lockMode)
+
+	query, args, err := selectChatBuilder.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var exists int
+	err = r.db.QueryRow(ctx, query, args...).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LockDeleted эксклюзивно блокирует строку удаленного чата до конца транзакции.
+func (r *repo) LockDeleted(ctx context.Context, chatID int64) (bool, error) {
+	selectChatBuilder := sq.
+		Select("1").
+		From("chats").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": chatID}).
+		Where(sq.NotEq{"deleted_at": nil}).
+		Suffix("FOR UPDATE")
+
+	query, args, err := selectChatBuilder.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var exists int
+	err = r.db.QueryRow(ctx, query, args...).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// IsDirect сообщает, является ли чат личным.
+func (r *repo) IsDirect(ctx context.Context, chatID int64) (bool, error) {
+	selectKindBuilder := sq.
+		Select("kind").
+		From("chats").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": chatID})
+
+	query, args, err := selectKindBuilder.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var kind string
+	err = r.db.QueryRow(ctx, query, args...).Scan(&kind)
+	if err != nil {
+		return false, err
+	}
+
+	return kind == model.ChatKindDirect, nil
+}
+
+// AllocateMessageSeq выделяет следующий порядковый номер сообщения в чате.
+//
+// Строка чата остается заблокированной до конца транзакции: сообщения одного чата вставляются
+// последовательно, а при откате транзакции номер освобождается, поэтому номера идут без пропусков.
+func (r *repo) AllocateMessageSeq(ctx context.Context, chatID int64) (int64, bool, error) {
+	allocateSeqBuilder := sq.
+		Update("chats").
+		PlaceholderFormat(sq.Dollar).
+		Set("last_message_seq", sq.Expr("last_message_seq + 1")).
+		Where(sq.Eq{"id": chatID, "deleted_at": nil}).
+		Suffix("RETURNING last_message_seq")
+
+	query, args, err := allocateSeqBuilder.ToSql()
+	if err != nil {
+		return 0, false, err
+	}
+
+	var seq int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&seq)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return seq, true, nil
+}
+
+// Update изменяет поля чата, отмеченные в update.
+func (r *repo) Update(ctx context.Context, chatID int64, update *model.ChatUpdate) error {
+	updateChatBuilder := sq.
+		Update("chats").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": chatID})
+
+	if update.UpdateName {
+		updateChatBuilder = updateChatBuilder.Set("name", update.Name)
+	}
+	if update.UpdateDescription {
+		updateChatBuilder = updateChatBuilder.Set("description", update.Description)
+	}
+
+	query, args, err := updateChatBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// Delete мягко удаляет чат: проставляет ему deleted_at.
+func (r *repo) Delete(ctx context.Context, chatID int64) error {
+	deleteChatBuilder := sq.
+		Update("chats").
+		PlaceholderFormat(sq.Dollar).
+		Set("deleted_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": chatID})
+
+	query, args, err := deleteChatBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// Restore восстанавливает чат, удаленный в пределах grace-периода.
+//
+// Личный чат не восстанавливается, если у его пары пользователей уже есть другой неудаленный личный чат.
+func (r *repo) Restore(ctx context.Context, chatID int64, gracePeriod time.Duration) (bool, bool, error) {
+	restoreChatBuilder := sq.
+		Update("chats").
+		PlaceholderFormat(sq.Dollar).
+		Set("deleted_at", nil).
+		Where(sq.Eq{"id": chatID}).
+		Where(sq.Expr("deleted_at > NOW() - make_interval(secs => ?)", gracePeriod.Seconds()))
+
+	query, args, err := restoreChatBuilder.ToSql()
+	if err != nil {
+		return false, false, err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == directUsersIndex {
+		return false, true, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+
+	return result.RowsAffected() > 0, false, nil
+}
+
+// Purge окончательно удаляет чаты с истекшим grace-периодом.
+//
+// Участники и сообщения таких чатов удаляются каскадно внешними ключами БД.
+func (r *repo) Purge(ctx context.Context, gracePeriod time.Duration) (int64, error) {
+	purgeChatsBuilder := sq.
+		Delete("chats").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Expr("deleted_at <= NOW() - make_interval(secs => ?)", gracePeriod.Seconds()))
+
+	query, args, err := purgeChatsBuilder.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// List возвращает чаты пользователя вместе с последним сообщением и количеством
+// непрочитанных сообщений каждого чата.
+//
+// Время последней активности чата - время последнего сообщения, либо время создания чата, если сообщений нет.
+func (r *repo) List(ctx context.Context, userID int64, after *model.PageCursor, limit int64) ([]*model.ChatSummary, error) {
+	selectChatsBuilder := sq.
+		Select(
+			"c.id", "c.name", "c.description", "c.kind",
+			"(SELECT COUNT(*) FROM chat_users m WHERE m.chat_id = c.id)",
+			"lm.id", "lm.user_id", "lm.message", "lm.created_at", "lm.edited_at", "lm.deleted_at",
+			"COALESCE(lm.created_at, c.created_at) AS last_activity_at",
+			member.UnreadCountColumn,
+		).
+		From("chat_users cu").
+		Join("chats c ON c.id = cu.chat_id AND c.deleted_at IS NULL").
+		JoinClause(`LEFT JOIN LATERAL (
+			SELECT id, user_id, message, created_at, edited_at, deleted_at FROM chat_messages
+			WHERE chat_id = c.id
+			ORDER BY created_at DESC, id DESC
+			LIMIT 1
+		) lm ON true`).
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"cu.user_id": userID}).
+		OrderBy("last_activity_at DESC", "c.id DESC").
+		Limit(uint64(limit))
+
+	if after != nil {
+		selectChatsBuilder = selectChatsBuilder.
+			Where(sq.Expr("(COALESCE(lm.created_at, c.created_at), c.id) < (?, ?)", after.At, after.ID))
+	}
+
+	query, args, err := selectChatsBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chats := make([]*model.ChatSummary, 0, limit)
+	for rows.Next() {
+		var (
+			chat          model.ChatSummary
+			lastMessageID *int64
+			lastUserID    *int64
+			lastText      *string
+			lastCreatedAt *time.Time
+			lastEditedAt  *time.Time
+			lastDeletedAt *time.Time
+		)
+		err = rows.Scan(
+			&chat.ID, &chat.Name, &chat.Description, &chat.Kind, &chat.MemberCount,
+			&lastMessageID, &lastUserID, &lastText, &lastCreatedAt, &lastEditedAt, &lastDeletedAt,
+			&chat.LastActivityAt, &chat.UnreadCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastMessageID != nil {
+			chat.LastMessage = &model.Message{
+				ID:        *lastMessageID,
+				ChatID:    chat.ID,
+				UserID:    *lastUserID,
+				Text:      *lastText,
+				CreatedAt: *lastCreatedAt,
+				EditedAt:  lastEditedAt,
+				DeletedAt: lastDeletedAt,
+			}
+		}
+		chats = append(chats, &chat)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return chats, nil
+}
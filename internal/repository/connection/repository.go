@@ -0,0 +1,129 @@
+package connection
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/repository"
+)
+
+type repo struct {
+	db db.Client
+}
+
+// NewRepository создает репозиторий потоковых подключений пользователей.
+func NewRepository(db db.Client) repository.ConnectionRepository {
+	return &repo{
+		db: db,
+	}
+}
+
+// Create регистрирует подключение пользователя.
+func (r *repo) Create(ctx context.Context, userID int64, gracePeriod time.Duration) (int64, bool, error) {
+	// Подзапрос в RETURNING не видит вставленную строку,
+	// поэтому сообщает, был ли пользователь онлайн до этого подключения
+	insertConnectionBuilder := sq.
+		Insert("user_connections").
+		PlaceholderFormat(sq.Dollar).
+		Columns("user_id").
+		Values(userID).
+		Suffix(
+			"RETURNING id, EXISTS (SELECT 1 FROM user_connections WHERE user_id = ? AND last_seen_at >= NOW() - make_interval(secs => ?))",
+			userID, gracePeriod.Seconds(),
+		)
+
+	query, args, err := insertConnectionBuilder.ToSql()
+	if err != nil {
+		return 0, false, err
+	}
+
+	var (
+		connectionID int64
+		wasOnline    bool
+	)
+	err = r.db.QueryRow(ctx, query, args...).Scan(&connectionID, &wasOnline)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return connectionID, wasOnline, nil
+}
+
+// Touch подтверждает подключение.
+func (r *repo) Touch(ctx context.Context, connectionID int64) error {
+	touchConnectionBuilder := sq.
+		Update("user_connections").
+		PlaceholderFormat(sq.Dollar).
+		Set("last_seen_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": connectionID})
+
+	query, args, err := touchConnectionBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// DeleteExpired удаляет подключения с истекшим grace-периодом.
+//
+// DELETE ... RETURNING гарантирует, что каждое подключение удалит и обработает только один экземпляр сервера.
+// ID пользователя повторяется столько раз, сколько его подключений удалено.
+func (r *repo) DeleteExpired(ctx context.Context, gracePeriod time.Duration) ([]int64, error) {
+	deleteConnectionsBuilder := sq.
+		Delete("user_connections").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Expr("last_seen_at < NOW() - make_interval(secs => ?)", gracePeriod.Seconds())).
+		Suffix("RETURNING user_id")
+
+	query, args, err := deleteConnectionsBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return selectUserIDs(ctx, r.db, query, args...)
+}
+
+// OnlineUserIDs возвращает ID пользователей из userIDs, которые сейчас онлайн.
+func (r *repo) OnlineUserIDs(ctx context.Context, userIDs []int64, gracePeriod time.Duration) ([]int64, error) {
+	selectOnlineBuilder := sq.
+		Select("DISTINCT user_id").
+		From("user_connections").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"user_id": userIDs}).
+		Where(sq.Expr("last_seen_at >= NOW() - make_interval(secs => ?)", gracePeriod.Seconds()))
+
+	query, args, err := selectOnlineBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return selectUserIDs(ctx, r.db, query, args...)
+}
+
+// selectUserIDs выполняет запрос, возвращающий одну колонку с ID пользователей.
+func selectUserIDs(ctx context.Context, q db.Querier, query string, args ...interface{}) ([]int64, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := make([]int64, 0)
+	for rows.Next() {
+		var userID int64
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
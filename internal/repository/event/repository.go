@@ -0,0 +1,28 @@
+package event
+
+import (
+	"context"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/converter"
+	"github.com/anton0701/chat-server/internal/events"
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/repository"
+)
+
+type repo struct {
+	db db.Client
+}
+
+// NewRepository создает канал событий чатов на основе Postgres LISTEN/NOTIFY.
+func NewRepository(db db.Client) repository.EventRepository {
+	return &repo{
+		db: db,
+	}
+}
+
+// Notify отправляет событие чата всем экземплярам сервера.
+// Каждый экземпляр доставит его своим подключенным участникам чата.
+func (r *repo) Notify(ctx context.Context, chatID int64, event *model.ChatEvent) error {
+	return events.Notify(ctx, r.db, chatID, converter.ToChatEventFromService(event))
+}
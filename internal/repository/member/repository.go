@@ -0,0 +1,363 @@
+package member
+
+import (
+	"context"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/repository"
+)
+
+// UnreadCountColumn - выражение для количества непрочитанных участником сообщений.
+//
+// Ожидает, что строка участника выбрана из chat_users под псевдонимом cu. Непрочитанными считаются
+// неудаленные сообщения других участников, отправленные позже последнего прочитанного сообщения.
+const UnreadCountColumn = `(SELECT COUNT(*) FROM chat_messages um
+	WHERE um.chat_id = cu.chat_id AND um.user_id <> cu.user_id AND um.deleted_at IS NULL
+	AND (cu.last_read_message_id IS NULL
+		OR (um.created_at, um.id) > (SELECT rm.created_at, rm.id FROM chat_messages rm WHERE rm.id = cu.last_read_message_id)))`
+
+type repo struct {
+	db db.Client
+}
+
+// NewRepository создает репозиторий участников чатов.
+func NewRepository(db db.Client) repository.MemberRepository {
+	return &repo{
+		db: db,
+	}
+}
+
+// Add добавляет участников в чат.
+func (r *repo) Add(ctx context.Context, chatID int64, members []*model.ChatMember) error {
+	insertChatUsersBuilder := sq.
+		Insert("chat_users").
+		PlaceholderFormat(sq.Dollar).
+		Columns("chat_id", "user_id", "role")
+	for _, member := range members {
+		insertChatUsersBuilder = insertChatUsersBuilder.Values(chatID, member.UserID, member.Role)
+	}
+
+	query, args, err := insertChatUsersBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// AddMissing добавляет пользователей в чат. Существующие участники пропускаются.
+func (r *repo) AddMissing(ctx context.Context, chatID int64, userIDs []int64) error {
+	insertChatUsersBuilder := sq.
+		Insert("chat_users").
+		PlaceholderFormat(sq.Dollar).
+		Columns("chat_id", "user_id").
+		Suffix("ON CONFLICT (chat_id, user_id) DO NOTHING")
+	for _, userID := range userIDs {
+		insertChatUsersBuilder = insertChatUsersBuilder.Values(chatID, userID)
+	}
+
+	query, args, err := insertChatUsersBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// Remove удаляет пользователей из чата.
+func (r *repo) Remove(ctx context.Context, chatID int64, userIDs []int64) error {
+	deleteChatUsersBuilder := sq.
+		Delete("chat_users").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"chat_id": chatID, "user_id": userIDs})
+
+	query, args, err := deleteChatUsersBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// Check проверяет существование чата и членство пользователя в нем одним запросом.
+func (r *repo) Check(ctx context.Context, chatID, userID int64) (bool, bool, error) {
+	checkMemberBuilder := sq.
+		Select().
+		Column(sq.Expr("EXISTS (SELECT 1 FROM chats WHERE id = ? AND deleted_at IS NULL)", chatID)).
+		Column(sq.Expr("EXISTS (SELECT 1 FROM chat_users WHERE chat_id = ? AND user_id = ?)", chatID, userID)).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := checkMemberBuilder.ToSql()
+	if err != nil {
+		return false, false, err
+	}
+
+	var chatExists, isMember bool
+	err = r.db.QueryRow(ctx, query, args...).Scan(&chatExists, &isMember)
+	if err != nil {
+		return false, false, err
+	}
+
+	return chatExists, isMember, nil
+}
+
+// Lock блокирует запись участника чата до конца транзакции.
+func (r *repo) Lock(ctx context.Context, chatID, userID int64) (bool, error) {
+	selectChatUserBuilder := sq.
+		Select("1").
+		From("chat_users").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"chat_id": chatID, "user_id": userID}).
+		Suffix("FOR SHARE")
+
+	query, args, err := selectChatUserBuilder.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var exists int
+	err = r.db.QueryRow(ctx, query, args...).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Role возвращает роль пользователя в чате.
+func (r *repo) Role(ctx context.Context, chatID, userID int64) (string, bool, error) {
+	selectRoleBuilder := sq.
+		Select("role").
+		From("chat_users").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"chat_id": chatID, "user_id": userID})
+
+	query, args, err := selectRoleBuilder.ToSql()
+	if err != nil {
+		return "", false, err
+	}
+
+	var role string
+	err = r.db.QueryRow(ctx, query, args...).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return role, true, nil
+}
+
+// SetRole изменяет роль участника чата.
+func (r *repo) SetRole(ctx context.Context, chatID, userID int64, role string) error {
+	updateRoleBuilder := sq.
+		Update("chat_users").
+		PlaceholderFormat(sq.Dollar).
+		Set("role", role).
+		Where(sq.Eq{"chat_id": chatID, "user_id": userID})
+
+	query, args, err := updateRoleBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// UserIDs возвращает ID участников чата, отсортированные по возрастанию.
+func (r *repo) UserIDs(ctx context.Context, chatID int64) ([]int64, error) {
+	selectChatUsersBuilder := sq.
+		Select("user_id").
+		From("chat_users").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"chat_id": chatID}).
+		OrderBy("user_id")
+
+	query, args, err := selectChatUsersBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return selectIDs(ctx, r.db, query, args...)
+}
+
+// List возвращает участников чата с их ролями, отсортированных по ID пользователя.
+func (r *repo) List(ctx context.Context, chatID int64, userIDs ...int64) ([]*model.ChatMember, error) {
+	selectMembersBuilder := sq.
+		Select("user_id", "role").
+		From("chat_users").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"chat_id": chatID}).
+		OrderBy("user_id")
+	if len(userIDs) > 0 {
+		selectMembersBuilder = selectMembersBuilder.Where(sq.Eq{"user_id": userIDs})
+	}
+
+	query, args, err := selectMembersBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]*model.ChatMember, 0)
+	for rows.Next() {
+		var member model.ChatMember
+		if err = rows.Scan(&member.UserID, &member.Role); err != nil {
+			return nil, err
+		}
+		members = append(members, &member)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// ChatIDs возвращает ID неудаленных чатов пользователя.
+func (r *repo) ChatIDs(ctx context.Context, userID int64) ([]int64, error) {
+	selectChatsBuilder := sq.
+		Select("cu.chat_id").
+		From("chat_users cu").
+		Join("chats c ON c.id = cu.chat_id AND c.deleted_at IS NULL").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"cu.user_id": userID})
+
+	query, args, err := selectChatsBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return selectIDs(ctx, r.db, query, args...)
+}
+
+// MarkRead продвигает указатель прочтения участника.
+//
+// Указатель обновляется, только если новое сообщение отправлено позже уже прочитанного.
+func (r *repo) MarkRead(ctx context.Context, chatID, userID, messageID int64) error {
+	updatePointerBuilder := sq.
+		Update("chat_users cu").
+		PlaceholderFormat(sq.Dollar).
+		Set("last_read_message_id", messageID).
+		From("chat_messages nm").
+		Where(sq.Eq{"nm.id": messageID, "cu.chat_id": chatID, "cu.user_id": userID}).
+		Where(`(cu.last_read_message_id IS NULL
+			OR (nm.created_at, nm.id) > (SELECT rm.created_at, rm.id FROM chat_messages rm WHERE rm.id = cu.last_read_message_id))`)
+
+	query, args, err := updatePointerBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// ReadState возвращает указатель прочтения участника и количество непрочитанных им сообщений.
+func (r *repo) ReadState(ctx context.Context, chatID, userID int64) (*model.ReadState, bool, error) {
+	selectStateBuilder := sq.
+		Select("cu.last_read_message_id", UnreadCountColumn).
+		From("chat_users cu").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"cu.chat_id": chatID, "cu.user_id": userID})
+
+	query, args, err := selectStateBuilder.ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var lastReadMessageID *int64
+	state := &model.ReadState{
+		ChatID: chatID,
+		UserID: userID,
+	}
+	err = r.db.QueryRow(ctx, query, args...).Scan(&lastReadMessageID, &state.UnreadCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if lastReadMessageID != nil {
+		state.LastReadMessageID = *lastReadMessageID
+	}
+
+	return state, true, nil
+}
+
+// ReadPointers возвращает указатели прочтения участников чата, отсортированные по ID пользователя.
+func (r *repo) ReadPointers(ctx context.Context, chatID int64) ([]*model.ReadPointer, error) {
+	selectPointersBuilder := sq.
+		Select("cu.user_id", "rm.created_at", "rm.id").
+		From("chat_users cu").
+		Join("chat_messages rm ON rm.id = cu.last_read_message_id").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"cu.chat_id": chatID}).
+		OrderBy("cu.user_id")
+
+	query, args, err := selectPointersBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pointers := make([]*model.ReadPointer, 0)
+	for rows.Next() {
+		var pointer model.ReadPointer
+		if err = rows.Scan(&pointer.UserID, &pointer.At, &pointer.MessageID); err != nil {
+			return nil, err
+		}
+		pointers = append(pointers, &pointer)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pointers, nil
+}
+
+// selectIDs выполняет запрос, выбирающий одну колонку с ID, и возвращает ID в порядке выборки.
+func selectIDs(ctx context.Context, q db.Querier, query string, args ...interface{}) ([]int64, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,106 @@
+package member
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/client/db/pg"
+)
+
+// testDSNEnvName - переменная окружения с DSN базы с примененными миграциями.
+// Если она не задана, тесты, которым нужен Postgres, пропускаются.
+const testDSNEnvName = "PG_DSN"
+
+// beginTestTx открывает транзакцию в тестовой БД и возвращает клиент БД и контекст с этой транзакцией.
+// Транзакция откатывается после завершения теста, поэтому тестовые данные не сохраняются.
+func beginTestTx(t *testing.T) (context.Context, db.Client) {
+	t.Helper()
+
+	dsn := os.Getenv(testDSNEnvName)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnvName)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("unable to connect to database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("unable to begin transaction: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = tx.Rollback(context.Background())
+	})
+
+	return pg.MakeContextTx(ctx, tx), pg.NewDB(pool)
+}
+
+func TestMarkReadAdvancesPointerOnlyForward(t *testing.T) {
+	ctx, client := beginTestTx(t)
+	r := &repo{db: client}
+
+	const userID, authorID = 1, 2
+
+	var chatID int64
+	err := client.QueryRow(ctx, "INSERT INTO chats (name) VALUES ('mark read') RETURNING id").Scan(&chatID)
+	if err != nil {
+		t.Fatalf("unable to insert chat: %v", err)
+	}
+	_, err = client.Exec(ctx, "INSERT INTO chat_users (chat_id, user_id) VALUES ($1, $2), ($1, $3)", chatID, userID, authorID)
+	if err != nil {
+		t.Fatalf("unable to insert chat users: %v", err)
+	}
+
+	// Сообщения с явным временем отправки, чтобы порядок не зависел от часов БД
+	sentAt := time.Date(2024, 8, 26, 12, 0, 0, 0, time.UTC)
+	messageIDs := make([]int64, 3)
+	for i := range messageIDs {
+		err = client.QueryRow(ctx,
+			"INSERT INTO chat_messages (chat_id, user_id, message, seq, created_at) VALUES ($1, $2, 'text', $3, $4) RETURNING id",
+			chatID, authorID, i+1, sentAt.Add(time.Duration(i)*time.Minute),
+		).Scan(&messageIDs[i])
+		if err != nil {
+			t.Fatalf("unable to insert message: %v", err)
+		}
+	}
+
+	steps := []struct {
+		name      string
+		messageID int64
+		want      int64
+		unread    int64
+	}{
+		{name: "first mark", messageID: messageIDs[1], want: messageIDs[1], unread: 1},
+		{name: "older message", messageID: messageIDs[0], want: messageIDs[1], unread: 1},
+		{name: "newer message", messageID: messageIDs[2], want: messageIDs[2], unread: 0},
+	}
+	for _, step := range steps {
+		err = r.MarkRead(ctx, chatID, userID, step.messageID)
+		if err != nil {
+			t.Fatalf("%s: MarkRead returned error: %v", step.name, err)
+		}
+
+		state, found, stateErr := r.ReadState(ctx, chatID, userID)
+		if stateErr != nil {
+			t.Fatalf("%s: ReadState returned error: %v", step.name, stateErr)
+		}
+		if !found {
+			t.Fatalf("%s: read state not found", step.name)
+		}
+		if state.LastReadMessageID != step.want {
+			t.Errorf("%s: last read message = %d, want %d", step.name, state.LastReadMessageID, step.want)
+		}
+		if state.UnreadCount != step.unread {
+			t.Errorf("%s: unread count = %d, want %d", step.name, state.UnreadCount, step.unread)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/repository"
+)
+
+// searchHeadlineOptions - параметры ts_headline для фрагментов найденных сообщений
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=3, MaxWords=20, MinWords=5"
+
+// messageColumns - колонки chat_messages в порядке, ожидаемом scanMessage.
+var messageColumns = []string{
+	"id", "chat_id", "user_id", "message", "created_at", "edited_at", "deleted_at", "reply_to_message_id",
+	"client_created_at", "seq",
+}
+
+type repo struct {
+	db db.Client
+}
+
+// NewRepository создает репозиторий сообщений.
+func NewRepository(db db.Client) repository.MessageRepository {
+	return &repo{
+		db: db,
+	}
+}
+
+// Create сохраняет сообщение. Время создания назначает БД.
+func (r *repo) Create(ctx context.Context, info *model.MessageInfo, seq int64) (*model.Message, error) {
+	var replyToMessageID *int64
+	if info.ReplyToMessageID != 0 {
+		replyToMessageID = &info.ReplyToMessageID
+	}
+
+	var idempotencyKey *string
+	if info.IdempotencyKey != "" {
+		idempotencyKey = &info.IdempotencyKey
+	}
+
+	insertMessageBuilder := sq.
+		Insert("chat_messages").
+		PlaceholderFormat(sq.Dollar).
+		Columns("chat_id", "user_id", "message", "client_created_at", "reply_to_message_id", "seq", "idempotency_key").
+		Values(info.ChatID, info.UserID, info.Text, info.ClientCreatedAt, replyToMessageID, seq, idempotencyKey).
+		Suffix("RETURNING " + strings.Join(messageColumns, ", "))
+
+	query, args, err := insertMessageBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMessage(r.db.QueryRow(ctx, query, args...))
+}
+
+// Get возвращает сообщение неудаленного чата.
+func (r *repo) Get(ctx context.Context, messageID int64, forUpdate bool) (*model.Message, bool, error) {
+	selectMessageBuilder := sq.
+		Select(messageColumns...).
+		From("chat_messages").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"id": messageID}).
+		Where(sq.Expr("chat_id IN (SELECT id FROM chats WHERE deleted_at IS NULL)"))
+	if forUpdate {
+		selectMessageBuilder = selectMessageBuilder.Suffix("FOR UPDATE")
+	}
+
+	query, args, err := selectMessageBuilder.ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	message, err := scanMessage(r.db.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return message, true, nil
+}
+
+// GetByIdempotencyKey возвращает сообщение, отправленное пользователем в чат с ключом идемпотентности.
+func (r *repo) GetByIdempotencyKey(ctx context.Context, chatID, userID int64, key string) (*model.Message, bool, error) {
+	selectSentBuilder := sq.
+		Select(messageColumns...).
+		From("chat_messages").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"chat_id": chatID, "user_id": userID, "idempotency_key": key})
+
+	query, args, err := selectSentBuilder.ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	message, err := scanMessage(r.db.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return message, true, nil
+}
+
+// List возвращает страницу сообщений чата.
+//
+// При пагинации вперед (After, AfterSeq) сообщения выбираются по возрастанию, в остальных случаях - по убыванию.
+func (r *repo) List(ctx context.Context, params *model.ListMessagesParams) ([]*model.Message, error) {
+	selectMessagesBuilder := sq.
+		Select(messageColumns...).
+		From("chat_messages").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"chat_id": params.ChatID}).
+		Limit(uint64(params.Limit))
+
+	switch {
+	case params.Before != nil:
+		selectMessagesBuilder = selectMessagesBuilder.
+			Where(sq.Expr("(created_at, id) < (?, ?)", params.Before.At, params.Before.ID)).
+			OrderBy("created_at DESC", "id DESC")
+	case params.After != nil:
+		selectMessagesBuilder = selectMessagesBuilder.
+			Where(sq.Expr("(created_at, id) > (?, ?)", params.After.At, params.After.ID)).
+			OrderBy("created_at ASC", "id ASC")
+	case params.AfterSeq != nil:
+		// При дозагрузке по номеру порядок определяется только номером, чтобы не допустить пропусков
+		selectMessagesBuilder = selectMessagesBuilder.
+			Where(sq.Gt{"seq": *params.AfterSeq}).
+			OrderBy("seq ASC")
+	default:
+		selectMessagesBuilder = selectMessagesBuilder.OrderBy("created_at DESC", "id DESC")
+	}
+
+	query, args, err := selectMessagesBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return selectMessages(ctx, r.db, query, args...)
+}
+
+// ListReplies возвращает прямые ответы на сообщение, упорядоченные по времени создания.
+func (r *repo) ListReplies(ctx context.Context, rootID int64, after *model.PageCursor, limit int64) ([]*model.Message, error) {
+	selectRepliesBuilder := sq.
+		Select(messageColumns...).
+		From("chat_messages").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"reply_to_message_id": rootID}).
+		OrderBy("created_at ASC", "id ASC").
+		Limit(uint64(limit))
+
+	if after != nil {
+		selectRepliesBuilder = selectRepliesBuilder.
+			Where(sq.Expr("(created_at, id) > (?, ?)", after.At, after.ID))
+	}
+
+	query, args, err := selectRepliesBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return selectMessages(ctx, r.db, query, args...)
+}
+
+// SaveEdit сохраняет текущий текст сообщения в историю правок.
+func (r *repo) SaveEdit(ctx context.Context, messageID int64) error {
+	insertEditBuilder := sq.
+		Insert("chat_message_edits").
+		PlaceholderFormat(sq.Dollar).
+		Columns("message_id", "message").
+		Select(sq.
+			Select("id", "message").
+			From("chat_messages").
+			Where(sq.Eq{"id": messageID}))
+
+	query, args, err := insertEditBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// DeleteEdits удаляет историю правок сообщения.
+func (r *repo) DeleteEdits(ctx context.Context, messageID int64) error {
+	deleteEditsBuilder := sq.
+		Delete("chat_message_edits").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"message_id": messageID})
+
+	query, args, err := deleteEditsBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// UpdateText изменяет текст сообщения и проставляет ему edited_at.
+func (r *repo) UpdateText(ctx context.Context, messageID int64, text string) (*model.Message, error) {
+	updateMessageBuilder := sq.
+		Update("chat_messages").
+		PlaceholderFormat(sq.Dollar).
+		Set("message", text).
+		Set("edited_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": messageID}).
+		Suffix("RETURNING " + strings.Join(messageColumns, ", "))
+
+	query, args, err := updateMessageBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMessage(r.db.QueryRow(ctx, query, args...))
+}
+
+// Delete заменяет сообщение "надгробием".
+func (r *repo) Delete(ctx context.Context, messageID int64) (*model.Message, error) {
+	deleteMessageBuilder := sq.
+		Update("chat_messages").
+		PlaceholderFormat(sq.Dollar).
+		Set("message", "").
+		Set("deleted_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": messageID}).
+		Suffix("RETURNING " + strings.Join(messageColumns, ", "))
+
+	query, args, err := deleteMessageBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMessage(r.db.QueryRow(ctx, query, args...))
+}
+
+// Search выполняет полнотекстовый поиск сообщений.
+//
+// Результаты содержат фрагменты текста с выделенными совпадениями (см. searchHeadlineOptions).
+func (r *repo) Search(ctx context.Context, params *model.SearchParams) ([]*model.SearchHit, error) {
+	searchMessagesBuilder := sq.
+		Select(qualifyColumns("m", messageColumns)...).
+		Column("ts_rank(m.message_tsv, q.query) AS rank").
+		Column(sq.Expr("ts_headline('simple', m.message, q.query, ?)", searchHeadlineOptions)).
+		From("chat_messages m").
+		JoinClause("CROSS JOIN websearch_to_tsquery('simple', ?) AS q(query)", params.Query).
+		Join("chats c ON c.id = m.chat_id AND c.deleted_at IS NULL").
+		Join("chat_users cu ON cu.chat_id = m.chat_id").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"cu.user_id": params.UserID, "m.deleted_at": nil}).
+		Where("m.message_tsv @@ q.query").
+		OrderBy("rank DESC", "m.id DESC").
+		Limit(uint64(params.Limit))
+
+	if params.ChatID != 0 {
+		searchMessagesBuilder = searchMessagesBuilder.Where(sq.Eq{"m.chat_id": params.ChatID})
+	}
+	if params.After != nil {
+		searchMessagesBuilder = searchMessagesBuilder.
+			Where(sq.Expr("(ts_rank(m.message_tsv, q.query), m.id) < (?::real, ?)", params.After.Rank, params.After.ID))
+	}
+
+	query, args, err := searchMessagesBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := make([]*model.SearchHit, 0, params.Limit)
+	for rows.Next() {
+		var hit model.SearchHit
+		hit.Message, err = scanMessage(rows, &hit.Rank, &hit.Snippet)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, &hit)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
+
+// selectMessages выполняет запрос, выбирающий колонки messageColumns, и возвращает сообщения в порядке выборки.
+func selectMessages(ctx context.Context, q db.Querier, query string, args ...interface{}) ([]*model.Message, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]*model.Message, 0)
+	for rows.Next() {
+		message, scanErr := scanMessage(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		messages = append(messages, message)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// qualifyColumns добавляет к колонкам columns псевдоним таблицы alias.
+func qualifyColumns(alias string, columns []string) []string {
+	qualified := make([]string, 0, len(columns))
+	for _, column := range columns {
+		qualified = append(qualified, alias+"."+column)
+	}
+
+	return qualified
+}
+
+// scanMessage считывает сообщение из строки результата, выбранной по колонкам messageColumns.
+//
+// Если после колонок сообщения выбраны дополнительные колонки, их значения считываются в extra.
+func scanMessage(row pgx.Row, extra ...interface{}) (*model.Message, error) {
+	var (
+		message   model.Message
+		replyToID *int64
+	)
+	dest := []interface{}{
+		&message.ID, &message.ChatID, &message.UserID, &message.Text, &message.CreatedAt, &message.EditedAt,
+		&message.DeletedAt, &replyToID, &message.ClientCreatedAt, &message.Seq,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if replyToID != nil {
+		message.ReplyToMessageID = *replyToID
+	}
+
+	return &message, nil
+}
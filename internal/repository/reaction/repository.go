@@ -0,0 +1,125 @@
+package reaction
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/repository"
+)
+
+type repo struct {
+	db db.Client
+}
+
+// NewRepository создает репозиторий реакций на сообщения.
+func NewRepository(db db.Client) repository.ReactionRepository {
+	return &repo{
+		db: db,
+	}
+}
+
+// Add ставит реакцию пользователя на сообщение. Повторная реакция пользователя игнорируется.
+func (r *repo) Add(ctx context.Context, messageID, userID int64, emoji string) (bool, error) {
+	insertReactionBuilder := sq.
+		Insert("chat_message_reactions").
+		PlaceholderFormat(sq.Dollar).
+		Columns("message_id", "user_id", "emoji").
+		Values(messageID, userID, emoji).
+		Suffix("ON CONFLICT DO NOTHING")
+
+	query, args, err := insertReactionBuilder.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// Remove снимает реакцию пользователя с сообщения.
+func (r *repo) Remove(ctx context.Context, messageID, userID int64, emoji string) (bool, error) {
+	deleteReactionBuilder := sq.
+		Delete("chat_message_reactions").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"message_id": messageID, "user_id": userID, "emoji": emoji})
+
+	query, args, err := deleteReactionBuilder.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteByMessage удаляет все реакции на сообщение.
+func (r *repo) DeleteByMessage(ctx context.Context, messageID int64) error {
+	deleteReactionsBuilder := sq.
+		Delete("chat_message_reactions").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"message_id": messageID})
+
+	query, args, err := deleteReactionsBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
+
+// List возвращает реакции на сообщения с ID из messageIDs.
+//
+// Реакции каждого сообщения упорядочены по времени появления первой такой реакции.
+func (r *repo) List(ctx context.Context, messageIDs ...int64) (map[int64][]*model.Reaction, error) {
+	reactions := make(map[int64][]*model.Reaction)
+	if len(messageIDs) == 0 {
+		return reactions, nil
+	}
+
+	selectReactionsBuilder := sq.
+		Select("message_id", "emoji", "COUNT(*)").
+		From("chat_message_reactions").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"message_id": messageIDs}).
+		GroupBy("message_id", "emoji").
+		OrderBy("message_id", "MIN(created_at)", "emoji")
+
+	query, args, err := selectReactionsBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			messageID int64
+			reaction  model.Reaction
+		)
+		if err = rows.Scan(&messageID, &reaction.Emoji, &reaction.Count); err != nil {
+			return nil, err
+		}
+		reactions[messageID] = append(reactions[messageID], &reaction)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reactions, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// Методы репозиториев выполняют запросы в транзакции из контекста, если сервисный слой ее открыл
+// (см. db.TxManager), иначе - на пуле соединений.
+
+// ChatRepository - хранилище чатов (таблица chats).
+//
+// Удаленными считаются чаты с проставленным deleted_at. Если не указано иное,
+// методы работают только с неудаленными чатами.
+type ChatRepository interface {
+	// Create создает групповой чат, владельцем которого становится info.OwnerID.
+	// Если владелец уже создал чат с тем же ключом идемпотентности, возвращает created = false.
+	Create(ctx context.Context, info *model.ChatInfo) (chatID int64, created bool, err error)
+	// GetIDByIdempotencyKey возвращает ID чата, созданного пользователем createdBy с ключом идемпотентности key.
+	GetIDByIdempotencyKey(ctx context.Context, createdBy int64, key string) (int64, error)
+	// CreateDirect создает личный чат нормализованной пары пользователей (userLow < userHigh).
+	// Если у пары уже есть личный чат, возвращает created = false.
+	CreateDirect(ctx context.Context, userLow, userHigh int64) (chatID int64, created bool, err error)
+	// GetDirectID возвращает ID личного чата нормализованной пары пользователей.
+	GetDirectID(ctx context.Context, userLow, userHigh int64) (int64, error)
+	// Get возвращает чат. Возвращает false, если чат не существует или удален.
+	Get(ctx context.Context, chatID int64) (*model.Chat, bool, error)
+	// Lock блокирует строку чата до конца транзакции: эксклюзивно (FOR UPDATE), если forUpdate,
+	// иначе разделяемо (FOR SHARE). Возвращает false, если чат не существует или удален.
+	Lock(ctx context.Context, chatID int64, forUpdate bool) (bool, error)
+	// LockDeleted эксклюзивно блокирует строку удаленного чата до конца транзакции.
+	// Возвращает false, если чат не существует или не удален.
+	LockDeleted(ctx context.Context, chatID int64) (bool, error)
+	// IsDirect сообщает, является ли чат личным.
+	IsDirect(ctx context.Context, chatID int64) (bool, error)
+	// AllocateMessageSeq выделяет следующий порядковый номер сообщения в чате и блокирует строку чата
+	// до конца транзакции. Возвращает false, если чат не существует или удален.
+	AllocateMessageSeq(ctx context.Context, chatID int64) (int64, bool, error)
+	// Update изменяет поля чата, отмеченные в update.
+	Update(ctx context.Context, chatID int64, update *model.ChatUpdate) error
+	// Delete мягко удаляет чат.
+	Delete(ctx context.Context, chatID int64) error
+	// Restore восстанавливает чат, удаленный не раньше, чем gracePeriod назад.
+	// Возвращает restored = false, если такого удаленного чата нет, и conflict = true, если чат личный,
+	// а у его пары пользователей уже есть другой неудаленный личный чат.
+	Restore(ctx context.Context, chatID int64, gracePeriod time.Duration) (restored bool, conflict bool, err error)
+	// Purge окончательно удаляет чаты, удаленные раньше, чем gracePeriod назад.
+	// Возвращает количество удаленных чатов.
+	Purge(ctx context.Context, gracePeriod time.Duration) (int64, error)
+	// List возвращает до limit чатов пользователя, упорядоченных по убыванию времени последней активности.
+	// Если after указан, возвращает чаты, следующие за ним.
+	List(ctx context.Context, userID int64, after *model.PageCursor, limit int64) ([]*model.ChatSummary, error)
+}
+
+// MemberRepository - хранилище участников чатов (таблица chat_users).
+type MemberRepository interface {
+	// Add добавляет участников в чат.
+	Add(ctx context.Context, chatID int64, members []*model.ChatMember) error
+	// AddMissing добавляет пользователей в чат с ролью member, пропуская уже состоящих в нем.
+	AddMissing(ctx context.Context, chatID int64, userIDs []int64) error
+	// Remove удаляет пользователей из чата.
+	Remove(ctx context.Context, chatID int64, userIDs []int64) error
+	// Check сообщает, существует ли неудаленный чат и состоит ли в нем пользователь.
+	Check(ctx context.Context, chatID, userID int64) (chatExists, isMember bool, err error)
+	// Lock блокирует запись участника до конца транзакции (FOR SHARE).
+	// Возвращает false, если пользователь не состоит в чате.
+	Lock(ctx context.Context, chatID, userID int64) (bool, error)
+	// Role возвращает роль пользователя в чате. Возвращает false, если пользователь не состоит в чате.
+	Role(ctx context.Context, chatID, userID int64) (string, bool, error)
+	// SetRole изменяет роль участника чата.
+	SetRole(ctx context.Context, chatID, userID int64, role string) error
+	// UserIDs возвращает ID участников чата, отсортированные по возрастанию.
+	UserIDs(ctx context.Context, chatID int64) ([]int64, error)
+	// List возвращает участников чата, отсортированных по ID пользователя.
+	// Если userIDs не пустой, возвращаются только участники из этого списка.
+	List(ctx context.Context, chatID int64, userIDs ...int64) ([]*model.ChatMember, error)
+	// ChatIDs возвращает ID неудаленных чатов пользователя.
+	ChatIDs(ctx context.Context, userID int64) ([]int64, error)
+	// MarkRead продвигает указатель прочтения участника до сообщения messageID,
+	// если оно отправлено позже уже прочитанного.
+	MarkRead(ctx context.Context, chatID, userID, messageID int64) error
+	// ReadState возвращает состояние прочтения чата участником.
+	// Возвращает false, если пользователь не состоит в чате.
+	ReadState(ctx context.Context, chatID, userID int64) (*model.ReadState, bool, error)
+	// ReadPointers возвращает указатели прочтения участников чата, которые хотя бы раз читали чат.
+	ReadPointers(ctx context.Context, chatID int64) ([]*model.ReadPointer, error)
+}
+
+// MessageRepository - хранилище сообщений (таблицы chat_messages и chat_message_edits).
+type MessageRepository interface {
+	// Create сохраняет сообщение с порядковым номером seq. Время создания назначает БД.
+	Create(ctx context.Context, info *model.MessageInfo, seq int64) (*model.Message, error)
+	// Get возвращает сообщение неудаленного чата. Если forUpdate, блокирует сообщение до конца транзакции.
+	// Возвращает false, если сообщение не существует или его чат удален.
+	Get(ctx context.Context, messageID int64, forUpdate bool) (*model.Message, bool, error)
+	// GetByIdempotencyKey возвращает сообщение, отправленное пользователем в чат с ключом идемпотентности key.
+	// Возвращает false, если такого сообщения нет.
+	GetByIdempotencyKey(ctx context.Context, chatID, userID int64, key string) (*model.Message, bool, error)
+	// List возвращает до params.Limit сообщений чата, следующих за курсором из params.
+	// При пагинации вперед (After, AfterSeq) сообщения упорядочены по возрастанию, иначе - по убыванию.
+	List(ctx context.Context, params *model.ListMessagesParams) ([]*model.Message, error)
+	// ListReplies возвращает до limit прямых ответов на сообщение rootID, упорядоченных по времени создания.
+	// Если after указан, возвращает ответы, следующие за ним.
+	ListReplies(ctx context.Context, rootID int64, after *model.PageCursor, limit int64) ([]*model.Message, error)
+	// SaveEdit сохраняет текущий текст сообщения в историю правок.
+	SaveEdit(ctx context.Context, messageID int64) error
+	// DeleteEdits удаляет историю правок сообщения.
+	DeleteEdits(ctx context.Context, messageID int64) error
+	// UpdateText изменяет текст сообщения и отмечает его отредактированным.
+	UpdateText(ctx context.Context, messageID int64, text string) (*model.Message, error)
+	// Delete заменяет сообщение "надгробием": удаляет его текст и проставляет deleted_at.
+	Delete(ctx context.Context, messageID int64) (*model.Message, error)
+	// Search выполняет полнотекстовый поиск по неудаленным сообщениям чатов пользователя
+	// и возвращает до params.Limit результатов, упорядоченных по убыванию релевантности.
+	Search(ctx context.Context, params *model.SearchParams) ([]*model.SearchHit, error)
+}
+
+// ReactionRepository - хранилище реакций на сообщения (таблица chat_message_reactions).
+type ReactionRepository interface {
+	// Add ставит реакцию пользователя. Возвращает false, если такая реакция уже стоит.
+	Add(ctx context.Context, messageID, userID int64, emoji string) (bool, error)
+	// Remove снимает реакцию пользователя. Возвращает false, если такой реакции нет.
+	Remove(ctx context.Context, messageID, userID int64, emoji string) (bool, error)
+	// DeleteByMessage удаляет все реакции на сообщение.
+	DeleteByMessage(ctx context.Context, messageID int64) error
+	// List возвращает реакции на сообщения по ID сообщения. Сообщений без реакций в map нет.
+	List(ctx context.Context, messageIDs ...int64) (map[int64][]*model.Reaction, error)
+}
+
+// ConnectionRepository - хранилище потоковых подключений пользователей (таблица user_connections).
+type ConnectionRepository interface {
+	// Create регистрирует подключение пользователя и сообщает, был ли пользователь онлайн до него:
+	// подтверждал ли он другое подключение не раньше, чем gracePeriod назад.
+	Create(ctx context.Context, userID int64, gracePeriod time.Duration) (connectionID int64, wasOnline bool, err error)
+	// Touch подтверждает подключение.
+	Touch(ctx context.Context, connectionID int64) error
+	// DeleteExpired удаляет подключения, не подтвержденные за gracePeriod, и возвращает ID их пользователей.
+	DeleteExpired(ctx context.Context, gracePeriod time.Duration) ([]int64, error)
+	// OnlineUserIDs возвращает ID пользователей из userIDs, подтверждавших подключение не раньше, чем gracePeriod назад.
+	OnlineUserIDs(ctx context.Context, userIDs []int64, gracePeriod time.Duration) ([]int64, error)
+}
+
+// EventRepository - канал событий чатов, общий для всех экземпляров сервера.
+type EventRepository interface {
+	// Notify отправляет событие участникам чата. Если вызван в транзакции,
+	// событие будет доставлено только после ее коммита.
+	Notify(ctx context.Context, chatID int64, event *model.ChatEvent) error
+}
@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/events"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// Connect проверяет, что чат существует и пользователь в нем состоит, подписывает пользователя на события чата
+// и отмечает его онлайн, пока не будет вызвана возвращенная функция release. Используется потоковыми
+// подключениями к чату.
+func (s *serv) Connect(ctx context.Context, chatID, userID int64) (*events.Subscriber, func(), error) {
+	// Подписываемся до проверки участника: если пользователя удалят из чата сразу после проверки,
+	// событие об удалении все равно попадет в подписку и завершит подключение
+	sub := s.hub.Subscribe(chatID, userID)
+
+	chatExists, isMember, err := s.memberRepository.Check(ctx, chatID, userID)
+	if err != nil {
+		s.hub.Unsubscribe(chatID, sub)
+		s.log.Error("Chat connection. Unable to check chat member", zap.Error(err))
+		return nil, nil, status.Errorf(codes.Internal, "Chat connection. Unable to check chat member, error: %v", err)
+	}
+	if !chatExists {
+		s.hub.Unsubscribe(chatID, sub)
+		return nil, nil, status.Errorf(codes.NotFound, "Chat with ID %d not found", chatID)
+	}
+	if !isMember {
+		s.hub.Unsubscribe(chatID, sub)
+		return nil, nil, status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", userID, chatID)
+	}
+
+	// Пока поток открыт, пользователь считается онлайн
+	untrack, err := s.presenceService.Track(ctx, userID)
+	if err != nil {
+		s.hub.Unsubscribe(chatID, sub)
+		s.log.Error("Chat connection. Unable to track user presence", zap.Error(err))
+		return nil, nil, status.Errorf(codes.Internal, "Chat connection. Unable to track user presence, error: %v", err)
+	}
+
+	release := func() {
+		s.hub.Unsubscribe(chatID, sub)
+		untrack()
+	}
+
+	return sub, release, nil
+}
+
+// SetTyping рассылает участникам чата изменение индикатора набора текста пользователем.
+// События набора текста не сохраняются в БД.
+func (s *serv) SetTyping(ctx context.Context, chatID, userID int64, typing bool) error {
+	err := s.eventRepository.Notify(ctx, chatID, &model.ChatEvent{
+		Typing: &model.TypingEvent{
+			ChatID: chatID,
+			UserID: userID,
+			Typing: typing,
+		},
+	})
+	if err != nil {
+		s.log.Error("Method Typing. Unable to notify chat members", zap.Error(err))
+		return status.Errorf(codes.Internal, "Method Typing. Unable to notify chat members, error: %v", err)
+	}
+
+	return nil
+}
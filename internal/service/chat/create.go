@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// Create создает групповой чат.
+//
+// Владельцем чата становится info.OwnerID, либо первый пользователь из info.UserIDs, если владелец не указан.
+// Владелец всегда становится участником чата.
+func (s *serv) Create(ctx context.Context, info *model.ChatInfo) (int64, error) {
+	var chatID int64
+
+	// Создаем транзакцию, чтобы выполнились все запросы к БД ИЛИ не выполнился ни один
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		chatInfo := *info
+		if chatInfo.OwnerID == 0 {
+			chatInfo.OwnerID = info.UserIDs[0]
+		}
+
+		var (
+			created bool
+			err     error
+		)
+		chatID, created, err = s.chatRepository.Create(ctx, &chatInfo)
+		if err != nil {
+			s.log.Error("Method Create-Chat. Unable to insert chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Create-Chat. Unable to insert chat, error: %v", err)
+		}
+		if !created {
+			// Повторный запрос: возвращаем чат, созданный ранее с тем же ключом
+			chatID, err = s.chatRepository.GetIDByIdempotencyKey(ctx, chatInfo.OwnerID, chatInfo.IdempotencyKey)
+			if err != nil {
+				s.log.Error("Method Create-Chat. Unable to select created chat", zap.Error(err))
+				return status.Errorf(codes.Internal, "Method Create-Chat. Unable to select created chat, error: %v", err)
+			}
+
+			s.log.Info("Method Create-Chat. Chat already created", zap.Int64("chat_id", chatID))
+			return nil
+		}
+
+		userIDs := chatInfo.UserIDs
+		if !containsID(userIDs, chatInfo.OwnerID) {
+			userIDs = append([]int64{chatInfo.OwnerID}, userIDs...)
+		}
+
+		members := make([]*model.ChatMember, 0, len(userIDs))
+		for _, userID := range userIDs {
+			role := model.RoleMember
+			if userID == chatInfo.OwnerID {
+				role = model.RoleOwner
+			}
+			members = append(members, &model.ChatMember{
+				UserID: userID,
+				Role:   role,
+			})
+		}
+
+		err = s.memberRepository.Add(ctx, chatID, members)
+		if err != nil {
+			s.log.Error("Method Create-Chat. Unable to insert chat users", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Create-Chat. Unable to insert chat users, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, service.TxError(s.log, "Method Create-Chat", err)
+	}
+
+	return chatID, nil
+}
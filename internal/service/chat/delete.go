@@ -0,0 +1,93 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// Delete мягко удаляет чат.
+//
+// Групповой чат может удалить только владелец, личный - любой из двух его участников.
+// Открытые подключения к чату завершаются.
+//
+// После окончания grace-периода чат вместе с участниками и сообщениями окончательно удаляется (см. PurgeDeleted).
+func (s *serv) Delete(ctx context.Context, chatID, userID int64) error {
+	// Создаем транзакцию, чтобы проверка прав и удаление выполнились атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		exists, err := s.chatRepository.Lock(ctx, chatID, true)
+		if err != nil {
+			s.log.Error("Method Delete-Chat. Unable to select chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Delete-Chat. Unable to select chat, error: %v", err)
+		}
+		if !exists {
+			s.log.Info("Method Delete-Chat. Chat not found", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.NotFound, "Chat with ID %d not found", chatID)
+		}
+
+		err = s.checkCanDelete(ctx, "Method Delete-Chat", chatID, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.chatRepository.Delete(ctx, chatID)
+		if err != nil {
+			s.log.Error("Method Delete-Chat. Unable to delete chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Delete-Chat. Unable to delete chat, error: %v", err)
+		}
+
+		// Подключения к удаленному чату завершаются по этому событию.
+		// Уведомление будет доставлено только после коммита транзакции
+		err = s.eventRepository.Notify(ctx, chatID, &model.ChatEvent{
+			ChatDeleted: &model.ChatDeleted{
+				ChatID: chatID,
+			},
+		})
+		if err != nil {
+			s.log.Error("Method Delete-Chat. Unable to notify chat members", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Delete-Chat. Unable to notify chat members, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return service.TxError(s.log, "Method Delete-Chat", err)
+	}
+
+	return nil
+}
+
+// checkCanDelete проверяет, что пользователь может удалить или восстановить чат.
+//
+// Групповой чат может удалить и восстановить только владелец. У личного чата владельца нет,
+// поэтому это может сделать любой из двух его участников.
+func (s *serv) checkCanDelete(ctx context.Context, method string, chatID, userID int64) error {
+	role, isMember, err := s.memberRepository.Role(ctx, chatID, userID)
+	if err != nil {
+		s.log.Error(method+". Unable to select chat member role", zap.Error(err))
+		return status.Errorf(codes.Internal, "%s. Unable to select chat member role, error: %v", method, err)
+	}
+	if role == model.RoleOwner {
+		return nil
+	}
+
+	// Участники есть только у существующих чатов, поэтому тип чата проверяем только для них
+	if isMember {
+		direct, err := s.chatRepository.IsDirect(ctx, chatID)
+		if err != nil {
+			s.log.Error(method+". Unable to select chat kind", zap.Error(err))
+			return status.Errorf(codes.Internal, "%s. Unable to select chat kind, error: %v", method, err)
+		}
+		if direct {
+			return nil
+		}
+	}
+
+	s.log.Info(method+". User is not allowed to delete chat", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+	return status.Errorf(codes.PermissionDenied, "User %d is not allowed to delete or restore chat %d", userID, chatID)
+}
@@ -0,0 +1,62 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// GetOrCreateDirect возвращает личный чат двух пользователей, создавая его при необходимости.
+//
+// Личный чат не имеет названия, оба пользователя становятся его обычными участниками.
+func (s *serv) GetOrCreateDirect(ctx context.Context, userA, userB int64) (int64, bool, error) {
+	// Нормализуем пару: меньший ID первым
+	userLow, userHigh := userA, userB
+	if userLow > userHigh {
+		userLow, userHigh = userHigh, userLow
+	}
+
+	var (
+		chatID  int64
+		created bool
+	)
+
+	// Создаем транзакцию, чтобы чат и его участники создались атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		var err error
+		chatID, created, err = s.chatRepository.CreateDirect(ctx, userLow, userHigh)
+		if err != nil {
+			s.log.Error("Method Get-Or-Create-Direct-Chat. Unable to insert chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Get-Or-Create-Direct-Chat. Unable to insert chat, error: %v", err)
+		}
+
+		if created {
+			err = s.memberRepository.Add(ctx, chatID, []*model.ChatMember{
+				{UserID: userLow, Role: model.RoleMember},
+				{UserID: userHigh, Role: model.RoleMember},
+			})
+			if err != nil {
+				s.log.Error("Method Get-Or-Create-Direct-Chat. Unable to insert chat users", zap.Error(err))
+				return status.Errorf(codes.Internal, "Method Get-Or-Create-Direct-Chat. Unable to insert chat users, error: %v", err)
+			}
+		} else {
+			chatID, err = s.chatRepository.GetDirectID(ctx, userLow, userHigh)
+			if err != nil {
+				s.log.Error("Method Get-Or-Create-Direct-Chat. Unable to select chat", zap.Error(err))
+				return status.Errorf(codes.Internal, "Method Get-Or-Create-Direct-Chat. Unable to select chat, error: %v", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, false, service.TxError(s.log, "Method Get-Or-Create-Direct-Chat", err)
+	}
+
+	return chatID, created, nil
+}
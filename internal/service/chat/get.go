@@ -0,0 +1,46 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// Get возвращает неудаленный чат и его участников с ролями. Чат могут получить только его участники.
+func (s *serv) Get(ctx context.Context, chatID, userID int64) (*model.Chat, []*model.ChatMember, error) {
+	chatExists, isMember, err := s.memberRepository.Check(ctx, chatID, userID)
+	if err != nil {
+		s.log.Error("Method Get-Chat. Unable to check chat member", zap.Error(err))
+		return nil, nil, status.Errorf(codes.Internal, "Method Get-Chat. Unable to check chat member, error: %v", err)
+	}
+	if !chatExists {
+		s.log.Info("Method Get-Chat. Chat not found", zap.Int64("chat_id", chatID))
+		return nil, nil, status.Errorf(codes.NotFound, "Chat with ID %d not found", chatID)
+	}
+	if !isMember {
+		s.log.Info("Method Get-Chat. User is not a member of chat", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+		return nil, nil, status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", userID, chatID)
+	}
+
+	chat, exists, err := s.chatRepository.Get(ctx, chatID)
+	if err != nil {
+		s.log.Error("Method Get-Chat. Unable to select chat", zap.Error(err))
+		return nil, nil, status.Errorf(codes.Internal, "Method Get-Chat. Unable to select chat, error: %v", err)
+	}
+	if !exists {
+		s.log.Info("Method Get-Chat. Chat not found", zap.Int64("chat_id", chatID))
+		return nil, nil, status.Errorf(codes.NotFound, "Chat with ID %d not found", chatID)
+	}
+
+	members, err := s.memberRepository.List(ctx, chatID)
+	if err != nil {
+		s.log.Error("Method Get-Chat. Unable to select chat users", zap.Error(err))
+		return nil, nil, status.Errorf(codes.Internal, "Method Get-Chat. Unable to select chat users, error: %v", err)
+	}
+
+	return chat, members, nil
+}
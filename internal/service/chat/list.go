@@ -0,0 +1,50 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// List возвращает чаты, в которых состоит пользователь, упорядоченные по времени последней активности.
+//
+// Текст последнего сообщения каждого чата сокращается до превью (см. messagePreview).
+func (s *serv) List(ctx context.Context, userID, limit int64, after *model.PageCursor) ([]*model.ChatSummary, bool, error) {
+	if limit == 0 {
+		limit = defaultListChatsLimit
+	}
+
+	// Запрашиваем на один чат больше, чтобы узнать, есть ли следующая страница
+	chats, err := s.chatRepository.List(ctx, userID, after, limit+1)
+	if err != nil {
+		s.log.Error("Method List-Chats. Unable to select chats", zap.Error(err))
+		return nil, false, status.Errorf(codes.Internal, "Method List-Chats. Unable to select chats, error: %v", err)
+	}
+
+	hasMore := int64(len(chats)) > limit
+	if hasMore {
+		chats = chats[:limit]
+	}
+
+	for _, chat := range chats {
+		if chat.LastMessage != nil {
+			chat.LastMessage.Text = messagePreview(chat.LastMessage.Text)
+		}
+	}
+
+	return chats, hasMore, nil
+}
+
+// messagePreview сокращает текст сообщения до messagePreviewLength символов.
+func messagePreview(text string) string {
+	runes := []rune(text)
+	if len(runes) <= messagePreviewLength {
+		return text
+	}
+
+	return string(runes[:messagePreviewLength]) + "…"
+}
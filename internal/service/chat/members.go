@@ -0,0 +1,174 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// AddMembers добавляет пользователей в чат.
+//
+// Пользователи, уже состоящие в чате, пропускаются. Добавлять участников могут владелец и администраторы чата.
+// Новые участники получают роль member.
+func (s *serv) AddMembers(ctx context.Context, chatID, actorID int64, userIDs []int64) ([]int64, error) {
+	var memberIDs []int64
+
+	// Создаем транзакцию, чтобы вернуть список участников, согласованный с изменениями
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		exists, err := s.chatRepository.Lock(ctx, chatID, false)
+		if err != nil {
+			s.log.Error("Method Add-Chat-Members. Unable to select chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Chat-Members. Unable to select chat, error: %v", err)
+		}
+		if !exists {
+			s.log.Info("Method Add-Chat-Members. Chat not found", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.NotFound, "Chat with ID %d not found", chatID)
+		}
+
+		// Состав участников личного чата изменить нельзя
+		direct, err := s.chatRepository.IsDirect(ctx, chatID)
+		if err != nil {
+			s.log.Error("Method Add-Chat-Members. Unable to select chat kind", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Chat-Members. Unable to select chat kind, error: %v", err)
+		}
+		if direct {
+			s.log.Info("Method Add-Chat-Members. Members of direct chat cannot be changed", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.FailedPrecondition, "Members of direct chat %d cannot be changed", chatID)
+		}
+
+		// Добавлять участников могут владелец и администраторы чата
+		actorRole, _, err := s.memberRepository.Role(ctx, chatID, actorID)
+		if err != nil {
+			s.log.Error("Method Add-Chat-Members. Unable to select chat member role", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Chat-Members. Unable to select chat member role, error: %v", err)
+		}
+		if !model.IsChatManager(actorRole) {
+			s.log.Info("Method Add-Chat-Members. User is not allowed to add members", zap.Int64("chat_id", chatID), zap.Int64("user_id", actorID))
+			return status.Errorf(codes.PermissionDenied, "User %d is not allowed to add members to chat %d", actorID, chatID)
+		}
+
+		err = s.memberRepository.AddMissing(ctx, chatID, userIDs)
+		if err != nil {
+			s.log.Error("Method Add-Chat-Members. Unable to insert chat users", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Chat-Members. Unable to insert chat users, error: %v", err)
+		}
+
+		memberIDs, err = s.memberRepository.UserIDs(ctx, chatID)
+		if err != nil {
+			s.log.Error("Method Add-Chat-Members. Unable to select chat users", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Chat-Members. Unable to select chat users, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, service.TxError(s.log, "Method Add-Chat-Members", err)
+	}
+
+	return memberIDs, nil
+}
+
+// RemoveMembers удаляет пользователей из чата.
+//
+// Пользователи, не состоящие в чате, пропускаются. Любой участник может удалить себя (выйти из чата).
+// Администраторы могут удалять участников, владелец - участников и администраторов.
+// Владельца удалить из чата нельзя. Открытые подключения удаленных участников к чату завершаются.
+func (s *serv) RemoveMembers(ctx context.Context, chatID, actorID int64, userIDs []int64) ([]int64, error) {
+	var memberIDs []int64
+
+	// Создаем транзакцию, чтобы вернуть список участников, согласованный с изменениями
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		exists, err := s.chatRepository.Lock(ctx, chatID, false)
+		if err != nil {
+			s.log.Error("Method Remove-Chat-Members. Unable to select chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Chat-Members. Unable to select chat, error: %v", err)
+		}
+		if !exists {
+			s.log.Info("Method Remove-Chat-Members. Chat not found", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.NotFound, "Chat with ID %d not found", chatID)
+		}
+
+		// Состав участников личного чата изменить нельзя
+		direct, err := s.chatRepository.IsDirect(ctx, chatID)
+		if err != nil {
+			s.log.Error("Method Remove-Chat-Members. Unable to select chat kind", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Chat-Members. Unable to select chat kind, error: %v", err)
+		}
+		if direct {
+			s.log.Info("Method Remove-Chat-Members. Members of direct chat cannot be changed", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.FailedPrecondition, "Members of direct chat %d cannot be changed", chatID)
+		}
+
+		actorRole, isMember, err := s.memberRepository.Role(ctx, chatID, actorID)
+		if err != nil {
+			s.log.Error("Method Remove-Chat-Members. Unable to select chat member role", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Chat-Members. Unable to select chat member role, error: %v", err)
+		}
+		if !isMember {
+			s.log.Info("Method Remove-Chat-Members. User is not a member of chat", zap.Int64("chat_id", chatID), zap.Int64("user_id", actorID))
+			return status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", actorID, chatID)
+		}
+
+		// Проверяем права на удаление каждого участника. Пользователи, не состоящие в чате, пропускаются
+		var removedIDs []int64
+		targets, err := s.memberRepository.List(ctx, chatID, userIDs...)
+		if err != nil {
+			s.log.Error("Method Remove-Chat-Members. Unable to select chat members", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Chat-Members. Unable to select chat members, error: %v", err)
+		}
+		for _, target := range targets {
+			removedIDs = append(removedIDs, target.UserID)
+			switch {
+			case target.Role == model.RoleOwner:
+				// Владелец не может покинуть чат или быть удален из него - чат можно только удалить
+				s.log.Info("Method Remove-Chat-Members. Unable to remove chat owner", zap.Int64("chat_id", chatID), zap.Int64("user_id", target.UserID))
+				return status.Errorf(codes.FailedPrecondition, "Owner %d cannot be removed from chat %d", target.UserID, chatID)
+			case target.UserID == actorID:
+				// Любой участник может выйти из чата
+			case target.Role == model.RoleAdmin && actorRole != model.RoleOwner,
+				!model.IsChatManager(actorRole):
+				s.log.Info("Method Remove-Chat-Members. User is not allowed to remove member", zap.Int64("chat_id", chatID), zap.Int64("user_id", actorID), zap.Int64("member_id", target.UserID))
+				return status.Errorf(codes.PermissionDenied, "User %d is not allowed to remove member %d from chat %d", actorID, target.UserID, chatID)
+			}
+		}
+
+		err = s.memberRepository.Remove(ctx, chatID, userIDs)
+		if err != nil {
+			s.log.Error("Method Remove-Chat-Members. Unable to delete chat users", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Chat-Members. Unable to delete chat users, error: %v", err)
+		}
+
+		// Подключения удаленных участников завершаются по этому событию.
+		// Уведомление будет доставлено только после коммита транзакции
+		if len(removedIDs) > 0 {
+			err = s.eventRepository.Notify(ctx, chatID, &model.ChatEvent{
+				MembersRemoved: &model.ChatMembersRemoved{
+					ChatID:  chatID,
+					UserIDs: removedIDs,
+				},
+			})
+			if err != nil {
+				s.log.Error("Method Remove-Chat-Members. Unable to notify chat members", zap.Error(err))
+				return status.Errorf(codes.Internal, "Method Remove-Chat-Members. Unable to notify chat members, error: %v", err)
+			}
+		}
+
+		memberIDs, err = s.memberRepository.UserIDs(ctx, chatID)
+		if err != nil {
+			s.log.Error("Method Remove-Chat-Members. Unable to select chat users", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Chat-Members. Unable to select chat users, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, service.TxError(s.log, "Method Remove-Chat-Members", err)
+	}
+
+	return memberIDs, nil
+}
@@ -0,0 +1,20 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// PurgeDeleted выполняет одну итерацию окончательного удаления чатов, grace-период которых истек.
+func (s *serv) PurgeDeleted(ctx context.Context) {
+	purged, err := s.chatRepository.Purge(ctx, s.config.DeleteGracePeriod())
+	if err != nil {
+		s.log.Error("Chat purge. Unable to purge chats", zap.Error(err))
+		return
+	}
+
+	if purged > 0 {
+		s.log.Info("Chat purge. Deleted chats purged", zap.Int64("count", purged))
+	}
+}
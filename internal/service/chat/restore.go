@@ -0,0 +1,57 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// Restore восстанавливает чат, удаленный не раньше, чем grace-период назад.
+//
+// Личный чат может восстановить любой из двух его участников, если у пары пользователей
+// еще нет другого личного чата.
+func (s *serv) Restore(ctx context.Context, chatID, userID int64) error {
+	// Создаем транзакцию, чтобы проверка прав и восстановление выполнились атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		exists, err := s.chatRepository.LockDeleted(ctx, chatID)
+		if err != nil {
+			s.log.Error("Method Restore-Chat. Unable to select chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Restore-Chat. Unable to select chat, error: %v", err)
+		}
+		if !exists {
+			s.log.Info("Method Restore-Chat. Deleted chat not found", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.NotFound, "Deleted chat with ID %d not found or can no longer be restored", chatID)
+		}
+
+		// Участники удаленного чата сохраняются до окончательного удаления
+		err = s.checkCanDelete(ctx, "Method Restore-Chat", chatID, userID)
+		if err != nil {
+			return err
+		}
+
+		restored, conflict, err := s.chatRepository.Restore(ctx, chatID, s.config.DeleteGracePeriod())
+		if err != nil {
+			s.log.Error("Method Restore-Chat. Unable to restore chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Restore-Chat. Unable to restore chat, error: %v", err)
+		}
+		if conflict {
+			s.log.Info("Method Restore-Chat. Direct chat already exists", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.FailedPrecondition, "Direct chat %d cannot be restored: users already have another direct chat", chatID)
+		}
+		if !restored {
+			s.log.Info("Method Restore-Chat. Deleted chat not found", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.NotFound, "Deleted chat with ID %d not found or can no longer be restored", chatID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return service.TxError(s.log, "Method Restore-Chat", err)
+	}
+
+	return nil
+}
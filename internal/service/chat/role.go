@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// SetMemberRole изменяет роль участника чата.
+//
+// Назначать и снимать администраторов может только владелец чата. Роль владельца изменить нельзя.
+func (s *serv) SetMemberRole(ctx context.Context, chatID, actorID, userID int64, role string) (*model.ChatMember, error) {
+	// Создаем транзакцию, чтобы проверка прав и изменение роли выполнились атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		exists, err := s.chatRepository.Lock(ctx, chatID, false)
+		if err != nil {
+			s.log.Error("Method Set-Chat-Member-Role. Unable to select chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Set-Chat-Member-Role. Unable to select chat, error: %v", err)
+		}
+		if !exists {
+			s.log.Info("Method Set-Chat-Member-Role. Chat not found", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.NotFound, "Chat with ID %d not found", chatID)
+		}
+
+		actorRole, _, err := s.memberRepository.Role(ctx, chatID, actorID)
+		if err != nil {
+			s.log.Error("Method Set-Chat-Member-Role. Unable to select chat member role", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Set-Chat-Member-Role. Unable to select chat member role, error: %v", err)
+		}
+		if actorRole != model.RoleOwner {
+			s.log.Info("Method Set-Chat-Member-Role. User is not the owner of chat", zap.Int64("chat_id", chatID), zap.Int64("user_id", actorID))
+			return status.Errorf(codes.PermissionDenied, "User %d is not the owner of chat %d", actorID, chatID)
+		}
+
+		memberRole, isMember, err := s.memberRepository.Role(ctx, chatID, userID)
+		if err != nil {
+			s.log.Error("Method Set-Chat-Member-Role. Unable to select chat member role", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Set-Chat-Member-Role. Unable to select chat member role, error: %v", err)
+		}
+		if !isMember {
+			s.log.Info("Method Set-Chat-Member-Role. Member not found", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+			return status.Errorf(codes.NotFound, "User %d is not a member of chat %d", userID, chatID)
+		}
+		if memberRole == model.RoleOwner {
+			return status.Errorf(codes.FailedPrecondition, "Role of chat owner %d cannot be changed", userID)
+		}
+
+		err = s.memberRepository.SetRole(ctx, chatID, userID, role)
+		if err != nil {
+			s.log.Error("Method Set-Chat-Member-Role. Unable to update role", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Set-Chat-Member-Role. Unable to update role, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, service.TxError(s.log, "Method Set-Chat-Member-Role", err)
+	}
+
+	return &model.ChatMember{
+		UserID: userID,
+		Role:   role,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package chat
+
+import (
+	"go.uber.org/zap"
+
+	env "github.com/anton0701/chat-server/config/env"
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/events"
+	"github.com/anton0701/chat-server/internal/repository"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+const (
+	// defaultListChatsLimit - количество чатов на странице, если клиент не указал limit
+	defaultListChatsLimit = 50
+	// messagePreviewLength - максимальная длина текста сообщения (в символах) в превью списка чатов
+	messagePreviewLength = 100
+)
+
+type serv struct {
+	txManager        db.TxManager
+	chatRepository   repository.ChatRepository
+	memberRepository repository.MemberRepository
+	eventRepository  repository.EventRepository
+	presenceService  service.PresenceService
+	hub              *events.Hub
+	config           env.ChatConfig
+	log              *zap.Logger
+}
+
+// NewService создает сервис чатов.
+func NewService(
+	txManager db.TxManager,
+	chatRepository repository.ChatRepository,
+	memberRepository repository.MemberRepository,
+	eventRepository repository.EventRepository,
+	presenceService service.PresenceService,
+	hub *events.Hub,
+	config env.ChatConfig,
+	logger *zap.Logger,
+) service.ChatService {
+	return &serv{
+		txManager:        txManager,
+		chatRepository:   chatRepository,
+		memberRepository: memberRepository,
+		eventRepository:  eventRepository,
+		presenceService:  presenceService,
+		hub:              hub,
+		config:           config,
+		log:              logger,
+	}
+}
+
+// containsID сообщает, содержится ли id в ids.
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
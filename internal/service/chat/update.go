@@ -0,0 +1,52 @@
+package chat
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// Update изменяет поля чата, отмеченные в update.
+func (s *serv) Update(ctx context.Context, chatID, userID int64, update *model.ChatUpdate) error {
+	// Создаем транзакцию, чтобы проверка прав и изменение выполнились атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		exists, err := s.chatRepository.Lock(ctx, chatID, true)
+		if err != nil {
+			s.log.Error("Method Update-Chat. Unable to select chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Update-Chat. Unable to select chat, error: %v", err)
+		}
+		if !exists {
+			s.log.Info("Method Update-Chat. Chat not found", zap.Int64("chat_id", chatID))
+			return status.Errorf(codes.NotFound, "Chat with ID %d not found", chatID)
+		}
+
+		// Изменять чат могут владелец и администраторы
+		role, _, err := s.memberRepository.Role(ctx, chatID, userID)
+		if err != nil {
+			s.log.Error("Method Update-Chat. Unable to select chat member role", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Update-Chat. Unable to select chat member role, error: %v", err)
+		}
+		if !model.IsChatManager(role) {
+			s.log.Info("Method Update-Chat. User is not allowed to update chat", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+			return status.Errorf(codes.PermissionDenied, "User %d is not allowed to update chat %d", userID, chatID)
+		}
+
+		err = s.chatRepository.Update(ctx, chatID, update)
+		if err != nil {
+			s.log.Error("Method Update-Chat. Unable to update chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Update-Chat. Unable to update chat, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return service.TxError(s.log, "Method Update-Chat", err)
+	}
+
+	return nil
+}
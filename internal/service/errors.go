@@ -0,0 +1,20 @@
+package service
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TxError возвращает клиенту ошибку, с которой завершилась транзакция метода method.
+//
+// Ошибки, которые сервис вернул изнутри транзакции, уже содержат gRPC-код и возвращаются как есть.
+// Ошибки открытия и коммита транзакции логируются и возвращаются с кодом Internal.
+func TxError(log *zap.Logger, method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	log.Error(method+". Unable to execute transaction", zap.Error(err))
+	return status.Errorf(codes.Internal, "%s. Unable to execute transaction, error: %v", method, err)
+}
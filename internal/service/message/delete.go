@@ -0,0 +1,86 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// Delete удаляет сообщение.
+//
+// Строка сообщения сохраняется для порядка истории и аудита, но его текст, история правок и реакции удаляются.
+// Подключенные участники чата получают событие message_deleted. Повторное удаление возвращает уже удаленное сообщение.
+func (s *serv) Delete(ctx context.Context, messageID, userID int64) (*model.Message, error) {
+	var message *model.Message
+
+	// Создаем транзакцию, чтобы удаление и уведомление выполнились атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		// Блокируем сообщение до конца транзакции
+		var (
+			found bool
+			err   error
+		)
+		message, found, err = s.messageRepository.Get(ctx, messageID, true)
+		if err != nil {
+			s.log.Error("Method Delete-Message. Unable to select message", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Delete-Message. Unable to select message, error: %v", err)
+		}
+		if !found {
+			s.log.Info("Method Delete-Message. Message not found", zap.Int64("message_id", messageID))
+			return status.Errorf(codes.NotFound, "Message with ID %d not found", messageID)
+		}
+		// Чужие сообщения могут удалять владелец и администраторы чата
+		if message.UserID != userID {
+			role, _, roleErr := s.memberRepository.Role(ctx, message.ChatID, userID)
+			if roleErr != nil {
+				s.log.Error("Method Delete-Message. Unable to select chat member role", zap.Error(roleErr))
+				return status.Errorf(codes.Internal, "Method Delete-Message. Unable to select chat member role, error: %v", roleErr)
+			}
+			if !model.IsChatManager(role) {
+				s.log.Info("Method Delete-Message. User is not allowed to delete message", zap.Int64("message_id", messageID), zap.Int64("user_id", userID))
+				return status.Errorf(codes.PermissionDenied, "User %d is not allowed to delete message %d", userID, messageID)
+			}
+		}
+		if message.DeletedAt != nil {
+			return nil
+		}
+
+		err = s.messageRepository.DeleteEdits(ctx, messageID)
+		if err != nil {
+			s.log.Error("Method Delete-Message. Unable to delete message edits", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Delete-Message. Unable to delete message edits, error: %v", err)
+		}
+
+		err = s.reactionRepository.DeleteByMessage(ctx, messageID)
+		if err != nil {
+			s.log.Error("Method Delete-Message. Unable to delete message reactions", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Delete-Message. Unable to delete message reactions, error: %v", err)
+		}
+
+		message, err = s.messageRepository.Delete(ctx, messageID)
+		if err != nil {
+			s.log.Error("Method Delete-Message. Unable to delete message", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Delete-Message. Unable to delete message, error: %v", err)
+		}
+
+		err = s.eventRepository.Notify(ctx, message.ChatID, &model.ChatEvent{
+			MessageDeleted: message,
+		})
+		if err != nil {
+			s.log.Error("Method Delete-Message. Unable to notify chat members", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Delete-Message. Unable to notify chat members, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, service.TxError(s.log, "Method Delete-Message", err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,78 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// Edit изменяет текст сообщения.
+//
+// Предыдущий текст сохраняется в историю правок, подключенные участники чата получают событие message_edited.
+func (s *serv) Edit(ctx context.Context, messageID, userID int64, text string) (*model.Message, error) {
+	var message *model.Message
+
+	// Создаем транзакцию, чтобы правка, запись в историю и уведомление выполнились атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		// Блокируем сообщение до конца транзакции
+		var (
+			found bool
+			err   error
+		)
+		message, found, err = s.messageRepository.Get(ctx, messageID, true)
+		if err != nil {
+			s.log.Error("Method Edit-Message. Unable to select message", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Edit-Message. Unable to select message, error: %v", err)
+		}
+		if !found {
+			s.log.Info("Method Edit-Message. Message not found", zap.Int64("message_id", messageID))
+			return status.Errorf(codes.NotFound, "Message with ID %d not found", messageID)
+		}
+		if message.UserID != userID {
+			s.log.Info("Method Edit-Message. User is not the author of message", zap.Int64("message_id", messageID), zap.Int64("user_id", userID))
+			return status.Errorf(codes.PermissionDenied, "User %d is not the author of message %d", userID, messageID)
+		}
+		if message.DeletedAt != nil {
+			s.log.Info("Method Edit-Message. Message is deleted", zap.Int64("message_id", messageID))
+			return status.Errorf(codes.FailedPrecondition, "Message %d is deleted", messageID)
+		}
+
+		err = s.messageRepository.SaveEdit(ctx, messageID)
+		if err != nil {
+			s.log.Error("Method Edit-Message. Unable to insert message edit", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Edit-Message. Unable to insert message edit, error: %v", err)
+		}
+
+		message, err = s.messageRepository.UpdateText(ctx, messageID, text)
+		if err != nil {
+			s.log.Error("Method Edit-Message. Unable to update message", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Edit-Message. Unable to update message, error: %v", err)
+		}
+
+		err = s.attachReactions(ctx, message)
+		if err != nil {
+			s.log.Error("Method Edit-Message. Unable to select message reactions", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Edit-Message. Unable to select message reactions, error: %v", err)
+		}
+
+		err = s.eventRepository.Notify(ctx, message.ChatID, &model.ChatEvent{
+			MessageEdited: message,
+		})
+		if err != nil {
+			s.log.Error("Method Edit-Message. Unable to notify chat members", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Edit-Message. Unable to notify chat members, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, service.TxError(s.log, "Method Edit-Message", err)
+	}
+
+	return message, nil
+}
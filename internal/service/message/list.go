@@ -0,0 +1,72 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// List возвращает страницу истории сообщений чата вместе с реакциями и списком прочитавших их участников.
+//
+// Страница всегда возвращается в порядке от старых сообщений к новым. Историю могут читать только участники чата.
+func (s *serv) List(ctx context.Context, params *model.ListMessagesParams) ([]*model.Message, bool, error) {
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultListMessagesLimit
+	}
+
+	// Историю сообщений могут читать только участники чата
+	chatExists, isMember, err := s.memberRepository.Check(ctx, params.ChatID, params.UserID)
+	if err != nil {
+		s.log.Error("Method List-Messages. Unable to check chat member", zap.Error(err))
+		return nil, false, status.Errorf(codes.Internal, "Method List-Messages. Unable to check chat member, error: %v", err)
+	}
+	if !chatExists {
+		s.log.Info("Method List-Messages. Chat not found", zap.Int64("chat_id", params.ChatID))
+		return nil, false, status.Errorf(codes.NotFound, "Chat with ID %d not found", params.ChatID)
+	}
+	if !isMember {
+		s.log.Info("Method List-Messages. User is not a member of chat", zap.Int64("chat_id", params.ChatID), zap.Int64("user_id", params.UserID))
+		return nil, false, status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", params.UserID, params.ChatID)
+	}
+
+	// Запрашиваем на одно сообщение больше, чтобы узнать, есть ли еще сообщения в направлении пагинации
+	pageParams := *params
+	pageParams.Limit = limit + 1
+
+	messages, err := s.messageRepository.List(ctx, &pageParams)
+	if err != nil {
+		s.log.Error("Method List-Messages. Unable to select messages", zap.Error(err))
+		return nil, false, status.Errorf(codes.Internal, "Method List-Messages. Unable to select messages, error: %v", err)
+	}
+
+	hasMore := int64(len(messages)) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	err = s.attachReactions(ctx, messages...)
+	if err != nil {
+		s.log.Error("Method List-Messages. Unable to select message reactions", zap.Error(err))
+		return nil, false, status.Errorf(codes.Internal, "Method List-Messages. Unable to select message reactions, error: %v", err)
+	}
+
+	err = s.attachReadBy(ctx, params.ChatID, messages...)
+	if err != nil {
+		s.log.Error("Method List-Messages. Unable to select read receipts", zap.Error(err))
+		return nil, false, status.Errorf(codes.Internal, "Method List-Messages. Unable to select read receipts, error: %v", err)
+	}
+
+	// При пагинации назад сообщения выбраны по убыванию - разворачиваем страницу
+	if params.After == nil && params.AfterSeq == nil {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+
+	return messages, hasMore, nil
+}
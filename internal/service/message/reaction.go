@@ -0,0 +1,162 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// AddReaction ставит реакцию пользователя на сообщение.
+//
+// Повторная постановка той же реакции ничего не меняет. Количество различных реакций на сообщение
+// ограничено maxMessageReactionKinds.
+func (s *serv) AddReaction(ctx context.Context, messageID, userID int64, emoji string) (*model.MessageReactions, error) {
+	var messageReactions *model.MessageReactions
+
+	// Создаем транзакцию, чтобы изменение реакций и уведомление выполнились атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		// Блокируем сообщение до конца транзакции, чтобы изменения реакций на одно сообщение выполнялись последовательно
+		message, found, err := s.messageRepository.Get(ctx, messageID, true)
+		if err != nil {
+			s.log.Error("Method Add-Reaction. Unable to select message", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Reaction. Unable to select message, error: %v", err)
+		}
+		if !found {
+			s.log.Info("Method Add-Reaction. Message not found", zap.Int64("message_id", messageID))
+			return status.Errorf(codes.NotFound, "Message with ID %d not found", messageID)
+		}
+		// На удаленные сообщения реакции не ставятся
+		if message.DeletedAt != nil {
+			s.log.Info("Method Add-Reaction. Message is deleted", zap.Int64("message_id", messageID))
+			return status.Errorf(codes.FailedPrecondition, "Message %d is deleted", messageID)
+		}
+
+		// Реакции могут ставить только участники чата
+		_, isMember, err := s.memberRepository.Role(ctx, message.ChatID, userID)
+		if err != nil {
+			s.log.Error("Method Add-Reaction. Unable to select chat member role", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Reaction. Unable to select chat member role, error: %v", err)
+		}
+		if !isMember {
+			s.log.Info("Method Add-Reaction. User is not a member of chat", zap.Int64("chat_id", message.ChatID), zap.Int64("user_id", userID))
+			return status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", userID, message.ChatID)
+		}
+
+		added, err := s.reactionRepository.Add(ctx, messageID, userID, emoji)
+		if err != nil {
+			s.log.Error("Method Add-Reaction. Unable to add reaction", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Reaction. Unable to add reaction, error: %v", err)
+		}
+
+		reactions, err := s.reactionRepository.List(ctx, messageID)
+		if err != nil {
+			s.log.Error("Method Add-Reaction. Unable to select message reactions", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Reaction. Unable to select message reactions, error: %v", err)
+		}
+		if len(reactions[messageID]) > maxMessageReactionKinds {
+			s.log.Info("Method Add-Reaction. Too many reactions on message", zap.Int64("message_id", messageID))
+			return status.Errorf(codes.FailedPrecondition, "Message %d has too many different reactions", messageID)
+		}
+
+		messageReactions = &model.MessageReactions{
+			MessageID: messageID,
+			ChatID:    message.ChatID,
+			Reactions: reactions[messageID],
+		}
+
+		// Реакции не изменились - уведомлять подписчиков не о чем
+		if !added {
+			return nil
+		}
+
+		err = s.eventRepository.Notify(ctx, message.ChatID, &model.ChatEvent{
+			ReactionsUpdated: messageReactions,
+		})
+		if err != nil {
+			s.log.Error("Method Add-Reaction. Unable to notify chat members", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Add-Reaction. Unable to notify chat members, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, service.TxError(s.log, "Method Add-Reaction", err)
+	}
+
+	return messageReactions, nil
+}
+
+// RemoveReaction снимает реакцию пользователя с сообщения.
+//
+// Снятие отсутствующей реакции ничего не меняет.
+func (s *serv) RemoveReaction(ctx context.Context, messageID, userID int64, emoji string) (*model.MessageReactions, error) {
+	var messageReactions *model.MessageReactions
+
+	// Создаем транзакцию, чтобы изменение реакций и уведомление выполнились атомарно
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		// Блокируем сообщение до конца транзакции, чтобы изменения реакций на одно сообщение выполнялись последовательно
+		message, found, err := s.messageRepository.Get(ctx, messageID, true)
+		if err != nil {
+			s.log.Error("Method Remove-Reaction. Unable to select message", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Reaction. Unable to select message, error: %v", err)
+		}
+		if !found {
+			s.log.Info("Method Remove-Reaction. Message not found", zap.Int64("message_id", messageID))
+			return status.Errorf(codes.NotFound, "Message with ID %d not found", messageID)
+		}
+
+		// Реакции могут снимать только участники чата
+		_, isMember, err := s.memberRepository.Role(ctx, message.ChatID, userID)
+		if err != nil {
+			s.log.Error("Method Remove-Reaction. Unable to select chat member role", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Reaction. Unable to select chat member role, error: %v", err)
+		}
+		if !isMember {
+			s.log.Info("Method Remove-Reaction. User is not a member of chat", zap.Int64("chat_id", message.ChatID), zap.Int64("user_id", userID))
+			return status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", userID, message.ChatID)
+		}
+
+		removed, err := s.reactionRepository.Remove(ctx, messageID, userID, emoji)
+		if err != nil {
+			s.log.Error("Method Remove-Reaction. Unable to remove reaction", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Reaction. Unable to remove reaction, error: %v", err)
+		}
+
+		reactions, err := s.reactionRepository.List(ctx, messageID)
+		if err != nil {
+			s.log.Error("Method Remove-Reaction. Unable to select message reactions", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Reaction. Unable to select message reactions, error: %v", err)
+		}
+
+		messageReactions = &model.MessageReactions{
+			MessageID: messageID,
+			ChatID:    message.ChatID,
+			Reactions: reactions[messageID],
+		}
+
+		// Реакции не изменились - уведомлять подписчиков не о чем
+		if !removed {
+			return nil
+		}
+
+		err = s.eventRepository.Notify(ctx, message.ChatID, &model.ChatEvent{
+			ReactionsUpdated: messageReactions,
+		})
+		if err != nil {
+			s.log.Error("Method Remove-Reaction. Unable to notify chat members", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Remove-Reaction. Unable to notify chat members, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, service.TxError(s.log, "Method Remove-Reaction", err)
+	}
+
+	return messageReactions, nil
+}
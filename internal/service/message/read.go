@@ -0,0 +1,50 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// MarkRead отмечает сообщения чата прочитанными пользователем.
+//
+// Указатель прочтения только продвигается вперед: отметка более раннего сообщения не меняет его.
+func (s *serv) MarkRead(ctx context.Context, chatID, userID, upToMessageID int64) (*model.ReadState, error) {
+	// Сообщения удаленных чатов не учитываем
+	message, found, err := s.messageRepository.Get(ctx, upToMessageID, false)
+	if err != nil {
+		s.log.Error("Method Mark-Read. Unable to select message", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Method Mark-Read. Unable to select message, error: %v", err)
+	}
+	if !found {
+		s.log.Info("Method Mark-Read. Message not found", zap.Int64("message_id", upToMessageID))
+		return nil, status.Errorf(codes.NotFound, "Message with ID %d not found", upToMessageID)
+	}
+	if message.ChatID != chatID {
+		s.log.Info("Method Mark-Read. Message from another chat", zap.Int64("message_id", upToMessageID), zap.Int64("chat_id", chatID))
+		return nil, status.Errorf(codes.InvalidArgument, "Message %d does not belong to chat %d", upToMessageID, chatID)
+	}
+
+	err = s.memberRepository.MarkRead(ctx, chatID, userID, upToMessageID)
+	if err != nil {
+		s.log.Error("Method Mark-Read. Unable to update read pointer", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Method Mark-Read. Unable to update read pointer, error: %v", err)
+	}
+
+	// Отсутствие состояния прочтения означает, что пользователь не состоит в чате
+	state, isMember, err := s.memberRepository.ReadState(ctx, chatID, userID)
+	if err != nil {
+		s.log.Error("Method Mark-Read. Unable to select read state", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Method Mark-Read. Unable to select read state, error: %v", err)
+	}
+	if !isMember {
+		s.log.Info("Method Mark-Read. User is not a member of chat", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID))
+		return nil, status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", userID, chatID)
+	}
+
+	return state, nil
+}
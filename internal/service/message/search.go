@@ -0,0 +1,53 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// Search выполняет полнотекстовый поиск по неудаленным сообщениям чатов, в которых состоит пользователь.
+//
+// Результаты упорядочены по убыванию релевантности.
+func (s *serv) Search(ctx context.Context, params *model.SearchParams) ([]*model.SearchHit, bool, error) {
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultSearchMessagesLimit
+	}
+
+	// При поиске в одном чате проверяем, что чат существует и пользователь в нем состоит
+	if params.ChatID != 0 {
+		chatExists, isMember, err := s.memberRepository.Check(ctx, params.ChatID, params.UserID)
+		if err != nil {
+			s.log.Error("Method Search-Messages. Unable to check chat member", zap.Error(err))
+			return nil, false, status.Errorf(codes.Internal, "Method Search-Messages. Unable to check chat member, error: %v", err)
+		}
+		if !chatExists {
+			return nil, false, status.Errorf(codes.NotFound, "Chat with ID %d not found", params.ChatID)
+		}
+		if !isMember {
+			return nil, false, status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", params.UserID, params.ChatID)
+		}
+	}
+
+	// Запрашиваем на один результат больше, чтобы узнать, есть ли следующая страница
+	pageParams := *params
+	pageParams.Limit = limit + 1
+
+	hits, err := s.messageRepository.Search(ctx, &pageParams)
+	if err != nil {
+		s.log.Error("Method Search-Messages. Unable to search messages", zap.Error(err))
+		return nil, false, status.Errorf(codes.Internal, "Method Search-Messages. Unable to search messages, error: %v", err)
+	}
+
+	hasMore := int64(len(hits)) > limit
+	if hasMore {
+		hits = hits[:limit]
+	}
+
+	return hits, hasMore, nil
+}
@@ -0,0 +1,118 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// Send отправляет сообщение от пользователя в чат.
+//
+// Отправитель должен состоять в чате. Если указан ReplyToMessageID, сообщение становится ответом
+// на неудаленное сообщение того же чата. Проверки выполняются в одной транзакции со вставкой сообщения.
+// В той же транзакции сообщению выделяется следующий порядковый номер в чате.
+func (s *serv) Send(ctx context.Context, info *model.MessageInfo) (*model.Message, error) {
+	var message *model.Message
+
+	// Создаем транзакцию: уведомление о сообщении отправляется подписчикам только после коммита вставки
+	err := s.txManager.ReadCommitted(ctx, func(ctx context.Context) error {
+		// Проверяем, что чат существует, и блокируем его строку до конца транзакции:
+		// сообщения одного чата, в том числе повторы с тем же ключом идемпотентности, обрабатываются последовательно
+		exists, err := s.chatRepository.Lock(ctx, info.ChatID, true)
+		if err != nil {
+			s.log.Error("Method Send-Message. Unable to select chat", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Send-Message. Unable to select chat, error: %v", err)
+		}
+		if !exists {
+			s.log.Info("Method Send-Message. Chat not found", zap.Int64("chat_id", info.ChatID))
+			return status.Errorf(codes.NotFound, "Chat with ID %d not found", info.ChatID)
+		}
+
+		// Проверяем, что отправитель состоит в чате. Блокируем запись участника до конца транзакции
+		isMember, err := s.memberRepository.Lock(ctx, info.ChatID, info.UserID)
+		if err != nil {
+			s.log.Error("Method Send-Message. Unable to select chat user", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Send-Message. Unable to select chat user, error: %v", err)
+		}
+		if !isMember {
+			s.log.Info("Method Send-Message. User is not a member of chat", zap.Int64("chat_id", info.ChatID), zap.Int64("user_id", info.UserID))
+			return status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", info.UserID, info.ChatID)
+		}
+
+		// Повторный запрос с тем же ключом идемпотентности возвращает отправленное ранее сообщение.
+		// Параллельные повторы ждут блокировку строки чата, поэтому увидят сообщение после коммита первого запроса.
+		// Ключ проверяется до выделения порядкового номера, поэтому повтор не расходует номер
+		if info.IdempotencyKey != "" {
+			sent, found, selectErr := s.messageRepository.GetByIdempotencyKey(ctx, info.ChatID, info.UserID, info.IdempotencyKey)
+			if selectErr != nil {
+				s.log.Error("Method Send-Message. Unable to select sent message", zap.Error(selectErr))
+				return status.Errorf(codes.Internal, "Method Send-Message. Unable to select sent message, error: %v", selectErr)
+			}
+			if found {
+				s.log.Info("Method Send-Message. Message already sent", zap.Int64("message_id", sent.ID))
+				message = sent
+				return nil
+			}
+		}
+
+		// Если сообщение является ответом, проверяем, что исходное сообщение существует и принадлежит тому же чату
+		if info.ReplyToMessageID != 0 {
+			replyTo, found, selectErr := s.messageRepository.Get(ctx, info.ReplyToMessageID, false)
+			if selectErr != nil {
+				s.log.Error("Method Send-Message. Unable to select reply to message", zap.Error(selectErr))
+				return status.Errorf(codes.Internal, "Method Send-Message. Unable to select reply to message, error: %v", selectErr)
+			}
+			if !found {
+				s.log.Info("Method Send-Message. Reply to message not found", zap.Int64("message_id", info.ReplyToMessageID))
+				return status.Errorf(codes.NotFound, "Message with ID %d not found", info.ReplyToMessageID)
+			}
+			if replyTo.ChatID != info.ChatID {
+				s.log.Info("Method Send-Message. Reply to message from another chat", zap.Int64("message_id", info.ReplyToMessageID), zap.Int64("chat_id", info.ChatID))
+				return status.Errorf(codes.InvalidArgument, "Message %d does not belong to chat %d", info.ReplyToMessageID, info.ChatID)
+			}
+			if replyTo.DeletedAt != nil {
+				s.log.Info("Method Send-Message. Reply to deleted message", zap.Int64("message_id", info.ReplyToMessageID))
+				return status.Errorf(codes.FailedPrecondition, "Message %d is deleted", info.ReplyToMessageID)
+			}
+		}
+
+		// Порядковый номер выделяем только после всех проверок: транзакция, завершившаяся без вставки сообщения
+		// (в том числе повтор запроса), коммитится и не должна оставлять пропуск в нумерации
+		seq, exists, err := s.chatRepository.AllocateMessageSeq(ctx, info.ChatID)
+		if err != nil {
+			s.log.Error("Method Send-Message. Unable to allocate message seq", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Send-Message. Unable to allocate message seq, error: %v", err)
+		}
+		if !exists {
+			s.log.Info("Method Send-Message. Chat not found", zap.Int64("chat_id", info.ChatID))
+			return status.Errorf(codes.NotFound, "Chat with ID %d not found", info.ChatID)
+		}
+
+		message, err = s.messageRepository.Create(ctx, info, seq)
+		if err != nil {
+			s.log.Error("Method Send-Message. Unable to send message", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Send-Message. Unable to send message, error: %v", err)
+		}
+
+		// Уведомляем все экземпляры сервера о новом сообщении
+		err = s.eventRepository.Notify(ctx, info.ChatID, &model.ChatEvent{
+			Message: message,
+		})
+		if err != nil {
+			s.log.Error("Method Send-Message. Unable to notify chat members", zap.Error(err))
+			return status.Errorf(codes.Internal, "Method Send-Message. Unable to notify chat members, error: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, service.TxError(s.log, "Method Send-Message", err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,138 @@
+package message
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/repository"
+)
+
+// fakeTxManager выполняет обработчик без транзакции: все изменения фейковых репозиториев
+// применяются сразу, как если бы транзакция закоммитилась.
+type fakeTxManager struct{}
+
+func (fakeTxManager) ReadCommitted(ctx context.Context, f db.Handler) error {
+	return f(ctx)
+}
+
+func (fakeTxManager) Serializable(ctx context.Context, f db.Handler) error {
+	return f(ctx)
+}
+
+// fakeChatRepository хранит счетчик порядковых номеров одного чата.
+type fakeChatRepository struct {
+	repository.ChatRepository
+	lastMessageSeq int64
+}
+
+func (r *fakeChatRepository) Lock(_ context.Context, _ int64, _ bool) (bool, error) {
+	return true, nil
+}
+
+func (r *fakeChatRepository) AllocateMessageSeq(_ context.Context, _ int64) (int64, bool, error) {
+	r.lastMessageSeq++
+	return r.lastMessageSeq, true, nil
+}
+
+// fakeMemberRepository считает участником чата любого пользователя.
+type fakeMemberRepository struct {
+	repository.MemberRepository
+}
+
+func (r *fakeMemberRepository) Lock(_ context.Context, _, _ int64) (bool, error) {
+	return true, nil
+}
+
+// fakeMessageRepository хранит отправленные сообщения в памяти.
+type fakeMessageRepository struct {
+	repository.MessageRepository
+	messages []*model.Message
+	keys     []string
+}
+
+func (r *fakeMessageRepository) Create(_ context.Context, info *model.MessageInfo, seq int64) (*model.Message, error) {
+	message := &model.Message{
+		ID:        int64(len(r.messages) + 1),
+		ChatID:    info.ChatID,
+		UserID:    info.UserID,
+		Text:      info.Text,
+		CreatedAt: time.Now(),
+		Seq:       seq,
+	}
+	r.messages = append(r.messages, message)
+	r.keys = append(r.keys, info.IdempotencyKey)
+	return message, nil
+}
+
+func (r *fakeMessageRepository) GetByIdempotencyKey(_ context.Context, chatID, userID int64, key string) (*model.Message, bool, error) {
+	for i, message := range r.messages {
+		if message.ChatID == chatID && message.UserID == userID && r.keys[i] == key {
+			return message, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+type fakeEventRepository struct {
+	repository.EventRepository
+}
+
+func (r *fakeEventRepository) Notify(_ context.Context, _ int64, _ *model.ChatEvent) error {
+	return nil
+}
+
+func TestSendIdempotentRetryDoesNotAdvanceSeq(t *testing.T) {
+	ctx := context.Background()
+	chatRepository := &fakeChatRepository{}
+	messageRepository := &fakeMessageRepository{}
+	s := NewService(
+		fakeTxManager{},
+		chatRepository,
+		&fakeMemberRepository{},
+		messageRepository,
+		nil,
+		&fakeEventRepository{},
+		zap.NewNop(),
+	)
+
+	info := &model.MessageInfo{
+		ChatID:         1,
+		UserID:         10,
+		Text:           "hello",
+		IdempotencyKey: "key-1",
+	}
+
+	first, err := s.Send(ctx, info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	retry, err := s.Send(ctx, info)
+	if err != nil {
+		t.Fatalf("unexpected error on retry: %v", err)
+	}
+	if retry.ID != first.ID || retry.Seq != first.Seq {
+		t.Fatalf("retry returned message %d (seq %d), want %d (seq %d)", retry.ID, retry.Seq, first.ID, first.Seq)
+	}
+	if chatRepository.lastMessageSeq != first.Seq {
+		t.Fatalf("retry advanced chat seq to %d, want %d", chatRepository.lastMessageSeq, first.Seq)
+	}
+
+	next, err := s.Send(ctx, &model.MessageInfo{
+		ChatID:         1,
+		UserID:         10,
+		Text:           "world",
+		IdempotencyKey: "key-2",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.Seq != first.Seq+1 {
+		t.Fatalf("next message seq is %d, want %d", next.Seq, first.Seq+1)
+	}
+}
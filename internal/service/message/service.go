@@ -0,0 +1,95 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/anton0701/chat-server/internal/client/db"
+	"github.com/anton0701/chat-server/internal/model"
+	"github.com/anton0701/chat-server/internal/repository"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+const (
+	// defaultListMessagesLimit - количество сообщений на странице, если клиент не указал limit
+	defaultListMessagesLimit = 50
+	// defaultListThreadLimit - количество ответов в треде на странице, если клиент не указал limit
+	defaultListThreadLimit = 50
+	// defaultSearchMessagesLimit - количество результатов поиска на странице, если клиент не указал limit
+	defaultSearchMessagesLimit = 20
+	// maxMessageReactionKinds - максимальное количество различных реакций на одно сообщение.
+	// Ограничение нужно, чтобы событие с реакциями гарантированно помещалось в NOTIFY
+	maxMessageReactionKinds = 50
+)
+
+type serv struct {
+	txManager          db.TxManager
+	chatRepository     repository.ChatRepository
+	memberRepository   repository.MemberRepository
+	messageRepository  repository.MessageRepository
+	reactionRepository repository.ReactionRepository
+	eventRepository    repository.EventRepository
+	log                *zap.Logger
+}
+
+// NewService создает сервис сообщений.
+func NewService(
+	txManager db.TxManager,
+	chatRepository repository.ChatRepository,
+	memberRepository repository.MemberRepository,
+	messageRepository repository.MessageRepository,
+	reactionRepository repository.ReactionRepository,
+	eventRepository repository.EventRepository,
+	logger *zap.Logger,
+) service.MessageService {
+	return &serv{
+		txManager:          txManager,
+		chatRepository:     chatRepository,
+		memberRepository:   memberRepository,
+		messageRepository:  messageRepository,
+		reactionRepository: reactionRepository,
+		eventRepository:    eventRepository,
+		log:                logger,
+	}
+}
+
+// attachReactions заполняет реакции у сообщений messages.
+func (s *serv) attachReactions(ctx context.Context, messages ...*model.Message) error {
+	messageIDs := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		messageIDs = append(messageIDs, message.ID)
+	}
+
+	reactions, err := s.reactionRepository.List(ctx, messageIDs...)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		message.Reactions = reactions[message.ID]
+	}
+
+	return nil
+}
+
+// attachReadBy заполняет у сообщений чата chatID список прочитавших их участников.
+//
+// Автор сообщения в список не включается.
+func (s *serv) attachReadBy(ctx context.Context, chatID int64, messages ...*model.Message) error {
+	pointers, err := s.memberRepository.ReadPointers(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		message.ReadByUserIDs = nil
+		for _, pointer := range pointers {
+			if pointer.UserID != message.UserID && pointer.Covers(message) {
+				message.ReadByUserIDs = append(message.ReadByUserIDs, pointer.UserID)
+			}
+		}
+	}
+
+	return nil
+}
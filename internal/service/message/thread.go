@@ -0,0 +1,67 @@
+package message
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// ListThread возвращает тред сообщения: само сообщение и страницу прямых ответов на него,
+// упорядоченных по времени создания. Тред могут читать только участники чата корневого сообщения.
+func (s *serv) ListThread(ctx context.Context, rootID, userID, limit int64, after *model.PageCursor) (*model.Message, []*model.Message, bool, error) {
+	if limit == 0 {
+		limit = defaultListThreadLimit
+	}
+
+	// Сообщения удаленных чатов не возвращаем
+	root, found, err := s.messageRepository.Get(ctx, rootID, false)
+	if err != nil {
+		s.log.Error("Method List-Thread. Unable to select root message", zap.Error(err))
+		return nil, nil, false, status.Errorf(codes.Internal, "Method List-Thread. Unable to select root message, error: %v", err)
+	}
+	if !found {
+		s.log.Info("Method List-Thread. Message not found", zap.Int64("message_id", rootID))
+		return nil, nil, false, status.Errorf(codes.NotFound, "Message with ID %d not found", rootID)
+	}
+
+	_, isMember, err := s.memberRepository.Role(ctx, root.ChatID, userID)
+	if err != nil {
+		s.log.Error("Method List-Thread. Unable to select chat member role", zap.Error(err))
+		return nil, nil, false, status.Errorf(codes.Internal, "Method List-Thread. Unable to select chat member role, error: %v", err)
+	}
+	if !isMember {
+		s.log.Info("Method List-Thread. User is not a member of chat", zap.Int64("chat_id", root.ChatID), zap.Int64("user_id", userID))
+		return nil, nil, false, status.Errorf(codes.PermissionDenied, "User %d is not a member of chat %d", userID, root.ChatID)
+	}
+
+	// Запрашиваем на один ответ больше, чтобы узнать, есть ли следующая страница
+	replies, err := s.messageRepository.ListReplies(ctx, rootID, after, limit+1)
+	if err != nil {
+		s.log.Error("Method List-Thread. Unable to select replies", zap.Error(err))
+		return nil, nil, false, status.Errorf(codes.Internal, "Method List-Thread. Unable to select replies, error: %v", err)
+	}
+
+	hasMore := int64(len(replies)) > limit
+	if hasMore {
+		replies = replies[:limit]
+	}
+
+	threadMessages := append([]*model.Message{root}, replies...)
+	err = s.attachReactions(ctx, threadMessages...)
+	if err != nil {
+		s.log.Error("Method List-Thread. Unable to select message reactions", zap.Error(err))
+		return nil, nil, false, status.Errorf(codes.Internal, "Method List-Thread. Unable to select message reactions, error: %v", err)
+	}
+
+	err = s.attachReadBy(ctx, root.ChatID, threadMessages...)
+	if err != nil {
+		s.log.Error("Method List-Thread. Unable to select read receipts", zap.Error(err))
+		return nil, nil, false, status.Errorf(codes.Internal, "Method List-Thread. Unable to select read receipts, error: %v", err)
+	}
+
+	return root, replies, hasMore, nil
+}
@@ -0,0 +1,49 @@
+package presence
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ExpireConnections выполняет одну итерацию удаления устаревших подключений
+// и уведомляет участников чатов о пользователях, вышедших из сети.
+func (s *serv) ExpireConnections(ctx context.Context) {
+	expiredUserIDs, err := s.connectionRepository.DeleteExpired(ctx, s.config.PresenceGracePeriod())
+	if err != nil {
+		s.log.Error("Presence. Unable to expire connections", zap.Error(err))
+		return
+	}
+
+	// У пользователя могло истечь сразу несколько подключений
+	seen := make(map[int64]struct{}, len(expiredUserIDs))
+	userIDs := make([]int64, 0, len(expiredUserIDs))
+	for _, userID := range expiredUserIDs {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+		userIDs = append(userIDs, userID)
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	// Пользователи, у которых остались активные подключения, все еще онлайн
+	onlineUserIDs, err := s.connectionRepository.OnlineUserIDs(ctx, userIDs, s.config.PresenceGracePeriod())
+	if err != nil {
+		s.log.Error("Presence. Unable to select online users", zap.Error(err))
+		return
+	}
+
+	online := make(map[int64]struct{}, len(onlineUserIDs))
+	for _, userID := range onlineUserIDs {
+		online[userID] = struct{}{}
+	}
+
+	for _, userID := range userIDs {
+		if _, ok := online[userID]; !ok {
+			s.notifyPresence(ctx, userID, false)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package presence
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// Get возвращает присутствие пользователей userIDs в порядке запроса.
+func (s *serv) Get(ctx context.Context, userIDs []int64) ([]*model.UserPresence, error) {
+	onlineUserIDs, err := s.connectionRepository.OnlineUserIDs(ctx, userIDs, s.config.PresenceGracePeriod())
+	if err != nil {
+		s.log.Error("Method Get-Presence. Unable to select online users", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Method Get-Presence. Unable to select online users, error: %v", err)
+	}
+
+	online := make(map[int64]struct{}, len(onlineUserIDs))
+	for _, userID := range onlineUserIDs {
+		online[userID] = struct{}{}
+	}
+
+	presences := make([]*model.UserPresence, 0, len(userIDs))
+	for _, userID := range userIDs {
+		_, isOnline := online[userID]
+		presences = append(presences, &model.UserPresence{
+			UserID: userID,
+			Online: isOnline,
+		})
+	}
+
+	return presences, nil
+}
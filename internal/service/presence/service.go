@@ -0,0 +1,40 @@
+package presence
+
+import (
+	"go.uber.org/zap"
+
+	env "github.com/anton0701/chat-server/config/env"
+	"github.com/anton0701/chat-server/internal/repository"
+	"github.com/anton0701/chat-server/internal/service"
+)
+
+// serv отслеживает присутствие пользователей по их активным потоковым подключениям.
+//
+// Каждое подключение хранится в таблице user_connections и подтверждается каждые PresenceHeartbeatInterval.
+// Пользователь онлайн, пока хотя бы одно его подключение подтверждалось не раньше, чем PresenceGracePeriod назад.
+// Поэтому после отключения (или падения экземпляра сервера) пользователь остается онлайн в течение grace-периода.
+// Об изменении присутствия узнают участники всех чатов пользователя через событие presence.
+type serv struct {
+	connectionRepository repository.ConnectionRepository
+	memberRepository     repository.MemberRepository
+	eventRepository      repository.EventRepository
+	config               env.ChatConfig
+	log                  *zap.Logger
+}
+
+// NewService создает сервис присутствия пользователей.
+func NewService(
+	connectionRepository repository.ConnectionRepository,
+	memberRepository repository.MemberRepository,
+	eventRepository repository.EventRepository,
+	config env.ChatConfig,
+	logger *zap.Logger,
+) service.PresenceService {
+	return &serv{
+		connectionRepository: connectionRepository,
+		memberRepository:     memberRepository,
+		eventRepository:      eventRepository,
+		config:               config,
+		log:                  logger,
+	}
+}
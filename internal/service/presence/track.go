@@ -0,0 +1,86 @@
+package presence
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// releaseTimeout - время на фиксацию отключения пользователя после завершения потока
+const releaseTimeout = 5 * time.Second
+
+// Track регистрирует потоковое подключение пользователя userID и подтверждает его, пока не будет вызвана
+// возвращенная функция release. Если до подключения пользователь был офлайн, участники его чатов
+// получают событие о появлении пользователя в сети.
+func (s *serv) Track(ctx context.Context, userID int64) (func(), error) {
+	connectionID, wasOnline, err := s.connectionRepository.Create(ctx, userID, s.config.PresenceGracePeriod())
+	if err != nil {
+		return nil, err
+	}
+
+	if !wasOnline {
+		s.notifyPresence(ctx, userID, true)
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(s.config.PresenceHeartbeatInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				s.touch(ctx, connectionID)
+			}
+		}
+	}()
+
+	release := func() {
+		close(done)
+		<-stopped
+
+		// Фиксируем время отключения: с него отсчитывается grace-период
+		releaseCtx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+		defer cancel()
+		s.touch(releaseCtx, connectionID)
+	}
+
+	return release, nil
+}
+
+// touch подтверждает подключение connectionID.
+func (s *serv) touch(ctx context.Context, connectionID int64) {
+	err := s.connectionRepository.Touch(ctx, connectionID)
+	if err != nil {
+		s.log.Error("Presence. Unable to touch connection", zap.Int64("connection_id", connectionID), zap.Error(err))
+	}
+}
+
+// notifyPresence рассылает изменение присутствия пользователя во все его неудаленные чаты.
+func (s *serv) notifyPresence(ctx context.Context, userID int64, online bool) {
+	chatIDs, err := s.memberRepository.ChatIDs(ctx, userID)
+	if err != nil {
+		s.log.Error("Presence. Unable to select user chats", zap.Error(err))
+		return
+	}
+
+	event := &model.ChatEvent{
+		Presence: &model.UserPresence{
+			UserID: userID,
+			Online: online,
+		},
+	}
+	for _, chatID := range chatIDs {
+		if err = s.eventRepository.Notify(ctx, chatID, event); err != nil {
+			s.log.Error("Presence. Unable to notify chat members", zap.Int64("chat_id", chatID), zap.Int64("user_id", userID), zap.Error(err))
+		}
+	}
+}
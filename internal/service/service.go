@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"github.com/anton0701/chat-server/internal/events"
+	"github.com/anton0701/chat-server/internal/model"
+)
+
+// Методы сервисов возвращают ошибки gRPC (status) с кодом, который получит клиент:
+// NotFound, PermissionDenied, FailedPrecondition и т.д. Прочие ошибки возвращаются с кодом Internal.
+
+// ChatService - бизнес-логика чатов и их участников.
+type ChatService interface {
+	// Create создает групповой чат и возвращает его ID. Повторный запрос владельца
+	// с тем же ключом идемпотентности возвращает ID созданного ранее чата.
+	Create(ctx context.Context, info *model.ChatInfo) (int64, error)
+	// Delete мягко удаляет чат. Удалить групповой чат может только владелец, личный - любой из его участников.
+	Delete(ctx context.Context, chatID, userID int64) error
+	// Restore восстанавливает чат, удаленный в пределах grace-периода. Права те же, что и у Delete.
+	Restore(ctx context.Context, chatID, userID int64) error
+	// Get возвращает чат и его участников. Чат могут получить только его участники.
+	Get(ctx context.Context, chatID, userID int64) (*model.Chat, []*model.ChatMember, error)
+	// Update изменяет чат. Изменять чат могут владелец и администраторы.
+	Update(ctx context.Context, chatID, userID int64, update *model.ChatUpdate) error
+	// List возвращает страницу чатов пользователя и признак того, что есть следующая страница.
+	// Если limit равен 0, используется размер страницы по умолчанию.
+	List(ctx context.Context, userID, limit int64, after *model.PageCursor) ([]*model.ChatSummary, bool, error)
+	// GetOrCreateDirect возвращает ID личного чата пары пользователей и признак того, что чат был создан.
+	GetOrCreateDirect(ctx context.Context, userA, userB int64) (int64, bool, error)
+	// AddMembers добавляет пользователей в чат и возвращает актуальный список его участников.
+	AddMembers(ctx context.Context, chatID, actorID int64, userIDs []int64) ([]int64, error)
+	// RemoveMembers удаляет пользователей из чата и возвращает актуальный список его участников.
+	RemoveMembers(ctx context.Context, chatID, actorID int64, userIDs []int64) ([]int64, error)
+	// SetMemberRole изменяет роль участника чата. Изменять роли может только владелец.
+	SetMemberRole(ctx context.Context, chatID, actorID, userID int64, role string) (*model.ChatMember, error)
+	// Connect проверяет, что пользователь может подключиться к чату, подписывает его на события чата
+	// и отмечает его присутствие в сети до вызова возвращенной функции release.
+	Connect(ctx context.Context, chatID, userID int64) (sub *events.Subscriber, release func(), err error)
+	// SetTyping рассылает участникам чата изменение индикатора набора текста пользователем.
+	SetTyping(ctx context.Context, chatID, userID int64, typing bool) error
+	// PurgeDeleted окончательно удаляет чаты, grace-период которых истек.
+	PurgeDeleted(ctx context.Context)
+}
+
+// MessageService - бизнес-логика сообщений, реакций и отметок о прочтении.
+type MessageService interface {
+	// Send отправляет сообщение в чат. Повторный запрос с тем же ключом идемпотентности
+	// возвращает отправленное ранее сообщение.
+	Send(ctx context.Context, info *model.MessageInfo) (*model.Message, error)
+	// List возвращает страницу сообщений чата в порядке от старых к новым и признак того,
+	// что есть еще сообщения в направлении пагинации.
+	List(ctx context.Context, params *model.ListMessagesParams) ([]*model.Message, bool, error)
+	// ListThread возвращает корневое сообщение, страницу ответов на него и признак того,
+	// что есть следующая страница. Тред могут читать только участники чата корневого сообщения.
+	ListThread(ctx context.Context, rootID, userID, limit int64, after *model.PageCursor) (*model.Message, []*model.Message, bool, error)
+	// Edit изменяет текст сообщения. Редактировать сообщение может только его автор.
+	Edit(ctx context.Context, messageID, userID int64, text string) (*model.Message, error)
+	// Delete удаляет сообщение. Удалить сообщение могут его автор, владелец и администраторы чата.
+	Delete(ctx context.Context, messageID, userID int64) (*model.Message, error)
+	// AddReaction ставит реакцию пользователя на сообщение и возвращает актуальный набор реакций.
+	AddReaction(ctx context.Context, messageID, userID int64, emoji string) (*model.MessageReactions, error)
+	// RemoveReaction снимает реакцию пользователя с сообщения и возвращает актуальный набор реакций.
+	RemoveReaction(ctx context.Context, messageID, userID int64, emoji string) (*model.MessageReactions, error)
+	// MarkRead отмечает сообщения чата до upToMessageID прочитанными пользователем.
+	MarkRead(ctx context.Context, chatID, userID, upToMessageID int64) (*model.ReadState, error)
+	// Search выполняет полнотекстовый поиск сообщений и возвращает страницу результатов
+	// и признак того, что есть следующая страница.
+	Search(ctx context.Context, params *model.SearchParams) ([]*model.SearchHit, bool, error)
+}
+
+// PresenceService - присутствие пользователей в сети.
+type PresenceService interface {
+	// Track регистрирует потоковое подключение пользователя и подтверждает его,
+	// пока не будет вызвана возвращенная функция release.
+	Track(ctx context.Context, userID int64) (release func(), err error)
+	// Get возвращает присутствие пользователей в порядке userIDs.
+	Get(ctx context.Context, userIDs []int64) ([]*model.UserPresence, error)
+	// ExpireConnections удаляет подключения с истекшим grace-периодом и уведомляет
+	// участников чатов о пользователях, вышедших из сети.
+	ExpireConnections(ctx context.Context)
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX chat_messages_chat_id_created_at_id_idx ON chat_messages (chat_id, created_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chat_messages_chat_id_created_at_id_idx;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Удаляем записи, оставшиеся от ранее удаленных чатов, иначе внешние ключи не создадутся
+DELETE FROM chat_users WHERE chat_id NOT IN (SELECT id FROM chats);
+DELETE FROM chat_messages WHERE chat_id NOT IN (SELECT id FROM chats);
+
+ALTER TABLE chat_users
+    ADD CONSTRAINT chat_users_chat_id_fkey
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE;
+
+ALTER TABLE chat_messages
+    ADD CONSTRAINT chat_messages_chat_id_fkey
+    FOREIGN KEY (chat_id) REFERENCES chats (id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE chat_messages DROP CONSTRAINT chat_messages_chat_id_fkey;
+ALTER TABLE chat_users DROP CONSTRAINT chat_users_chat_id_fkey;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chats ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX chats_deleted_at_idx ON chats (deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chats_deleted_at_idx;
+
+ALTER TABLE chats DROP COLUMN deleted_at;
+-- +goose StatementEnd
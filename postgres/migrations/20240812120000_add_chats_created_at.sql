@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chats ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- Первичный ключ (chat_id, user_id) не подходит для поиска чатов пользователя
+CREATE INDEX chat_users_user_id_idx ON chat_users (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chat_users_user_id_idx;
+
+ALTER TABLE chats DROP COLUMN created_at;
+-- +goose StatementEnd
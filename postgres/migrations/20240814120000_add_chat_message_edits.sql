@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_messages ADD COLUMN edited_at TIMESTAMP;
+
+CREATE TABLE chat_message_edits (
+    id SERIAL PRIMARY KEY,
+    message_id INT NOT NULL REFERENCES chat_messages (id) ON DELETE CASCADE,
+    -- Текст сообщения до редактирования
+    message TEXT NOT NULL,
+    edited_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX chat_message_edits_message_id_idx ON chat_message_edits (message_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE chat_message_edits;
+
+ALTER TABLE chat_messages DROP COLUMN edited_at;
+-- +goose StatementEnd
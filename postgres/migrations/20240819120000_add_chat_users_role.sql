@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_users
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'member'
+    CONSTRAINT chat_users_role_check CHECK (role IN ('owner', 'admin', 'member'));
+
+-- Порядок добавления участников существующих чатов неизвестен,
+-- поэтому владельцем назначаем участника с наименьшим ID
+UPDATE chat_users cu
+SET role = 'owner'
+WHERE cu.user_id = (SELECT MIN(user_id) FROM chat_users WHERE chat_id = cu.chat_id);
+
+-- У чата может быть только один владелец
+CREATE UNIQUE INDEX chat_users_owner_idx ON chat_users (chat_id) WHERE role = 'owner';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chat_users_owner_idx;
+
+ALTER TABLE chat_users DROP COLUMN role;
+-- +goose StatementEnd
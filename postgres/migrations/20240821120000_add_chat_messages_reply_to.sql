@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_messages
+    ADD COLUMN reply_to_message_id INT
+    CONSTRAINT chat_messages_reply_to_message_id_fkey REFERENCES chat_messages (id) ON DELETE SET NULL;
+
+CREATE INDEX chat_messages_reply_to_message_id_created_at_id_idx
+    ON chat_messages (reply_to_message_id, created_at, id)
+    WHERE reply_to_message_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chat_messages_reply_to_message_id_created_at_id_idx;
+
+ALTER TABLE chat_messages DROP COLUMN reply_to_message_id;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE chat_message_reactions (
+    message_id INT NOT NULL REFERENCES chat_messages (id) ON DELETE CASCADE,
+    user_id INT NOT NULL,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id, emoji)
+);
+
+CREATE INDEX chat_message_reactions_message_id_emoji_idx ON chat_message_reactions (message_id, emoji);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE chat_message_reactions;
+-- +goose StatementEnd
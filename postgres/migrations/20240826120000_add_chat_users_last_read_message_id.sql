@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chat_users
+    ADD COLUMN last_read_message_id INT
+    CONSTRAINT chat_users_last_read_message_id_fkey REFERENCES chat_messages (id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE chat_users DROP COLUMN last_read_message_id;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Активные потоковые подключения пользователей. Используются для определения присутствия (онлайн/офлайн)
+CREATE TABLE user_connections (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    -- Время последнего подтверждения подключения (heartbeat) либо время отключения
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX user_connections_user_id_last_seen_at_idx ON user_connections (user_id, last_seen_at);
+CREATE INDEX user_connections_last_seen_at_idx ON user_connections (last_seen_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_connections;
+-- +goose StatementEnd
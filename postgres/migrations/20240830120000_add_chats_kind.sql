@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE chats
+    ADD COLUMN kind TEXT NOT NULL DEFAULT 'group'
+    CONSTRAINT chats_kind_check CHECK (kind IN ('group', 'direct'));
+
+-- Участники личного чата в нормализованном порядке (меньший ID первым)
+ALTER TABLE chats
+    ADD COLUMN direct_user_low INT,
+    ADD COLUMN direct_user_high INT,
+    ADD CONSTRAINT chats_direct_users_check CHECK (
+        (kind = 'direct' AND direct_user_low IS NOT NULL AND direct_user_high IS NOT NULL AND direct_user_low < direct_user_high)
+        OR (kind = 'group' AND direct_user_low IS NULL AND direct_user_high IS NULL)
+    );
+
+-- У пары пользователей может быть только один неудаленный личный чат
+CREATE UNIQUE INDEX chats_direct_users_idx ON chats (direct_user_low, direct_user_high) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chats_direct_users_idx;
+
+ALTER TABLE chats
+    DROP COLUMN direct_user_high,
+    DROP COLUMN direct_user_low,
+    DROP COLUMN kind;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Время отправки по часам клиента. created_at теперь назначает сервер
+ALTER TABLE chat_messages ADD COLUMN client_created_at TIMESTAMP;
+ALTER TABLE chat_messages ALTER COLUMN created_at SET DEFAULT clock_timestamp();
+
+-- Порядковый номер сообщения в чате без пропусков. Последний выданный номер хранится в chats
+ALTER TABLE chats ADD COLUMN last_message_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE chat_messages ADD COLUMN seq BIGINT;
+
+-- Нумеруем существующие сообщения в порядке истории
+UPDATE chat_messages m
+SET seq = numbered.seq
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY chat_id ORDER BY created_at, id) AS seq
+    FROM chat_messages
+) numbered
+WHERE numbered.id = m.id;
+
+UPDATE chats c
+SET last_message_seq = (SELECT COALESCE(MAX(seq), 0) FROM chat_messages WHERE chat_id = c.id);
+
+ALTER TABLE chat_messages ALTER COLUMN seq SET NOT NULL;
+CREATE UNIQUE INDEX chat_messages_chat_id_seq_idx ON chat_messages (chat_id, seq);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chat_messages_chat_id_seq_idx;
+
+ALTER TABLE chat_messages DROP COLUMN seq;
+ALTER TABLE chats DROP COLUMN last_message_seq;
+
+ALTER TABLE chat_messages ALTER COLUMN created_at SET DEFAULT NOW();
+ALTER TABLE chat_messages DROP COLUMN client_created_at;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ключ идемпотентности, сгенерированный клиентом. Повторный запрос с тем же ключом возвращает исходное сообщение
+ALTER TABLE chat_messages ADD COLUMN idempotency_key TEXT;
+
+CREATE UNIQUE INDEX chat_messages_idempotency_key_idx
+    ON chat_messages (chat_id, user_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+-- Создатель чата (владелец на момент создания) и ключ идемпотентности запроса создания
+ALTER TABLE chats
+    ADD COLUMN created_by INT,
+    ADD COLUMN idempotency_key TEXT;
+
+UPDATE chats c
+SET created_by = cu.user_id
+FROM chat_users cu
+WHERE cu.chat_id = c.id AND cu.role = 'owner';
+
+CREATE UNIQUE INDEX chats_idempotency_key_idx
+    ON chats (created_by, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chats_idempotency_key_idx;
+
+ALTER TABLE chats
+    DROP COLUMN idempotency_key,
+    DROP COLUMN created_by;
+
+DROP INDEX chat_messages_idempotency_key_idx;
+
+ALTER TABLE chat_messages DROP COLUMN idempotency_key;
+-- +goose StatementEnd
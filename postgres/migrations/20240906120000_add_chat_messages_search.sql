@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Конфигурация 'simple' не зависит от языка: в чатах встречаются сообщения на разных языках
+ALTER TABLE chat_messages
+    ADD COLUMN message_tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', message)) STORED;
+
+CREATE INDEX chat_messages_message_tsv_idx ON chat_messages USING GIN (message_tsv);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX chat_messages_message_tsv_idx;
+
+ALTER TABLE chat_messages DROP COLUMN message_tsv;
+-- +goose StatementEnd